@@ -0,0 +1,229 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fedeztk/got/internal/batch"
+	"github.com/fedeztk/got/internal/model"
+	"github.com/fedeztk/got/pkg/translator"
+	"golang.org/x/term"
+)
+
+// checkpointInterval is how often the output of got batch is saved while translating
+const checkpointInterval = 5 * time.Second
+
+func runBatch(args []string) {
+	flags := flag.NewFlagSet("batch", flag.ExitOnError)
+	ff := addFileFlags(flags, "auto", "language of the column to translate, detected by default", "comma separated list of languages to translate to, required")
+	column := flags.String(
+		"column",
+		"1",
+		"column to translate, by name in the header or by 1-based number",
+	)
+	noHeader := flags.Bool(
+		"no-header",
+		false,
+		"the first row is not a header but data to translate",
+	)
+	workers := flags.Int(
+		"workers",
+		translator.MaxWorkers,
+		"number of requests sent at the same time",
+	)
+	rate := flags.Float64(
+		"rate",
+		0,
+		"maximum number of requests a second, no limit when 0",
+	)
+	out := flags.String(
+		"out",
+		"",
+		"file to write the table to, by default the input file with .translated\nbefore its extension. When it exists the translation resumes from it",
+	)
+	restart := flags.Bool(
+		"restart",
+		false,
+		"translate everything again instead of resuming from the output file",
+	)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), `Usage:
+  got batch [flags] file.csv|file.tsv
+
+Translates a column of a CSV or TSV table into a new column per target
+language, named after the column and the language, e.g. text_it, or added
+after the last column when the table has no header.
+The output is saved as the translation goes: when interrupted, run the same
+command again to translate the cells that are still empty.
+
+Flags:
+`)
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+	if flags.NArg() != 1 {
+		flags.Usage()
+		os.Exit(1)
+	}
+	languages := translator.ParseTargets(*ff.target)
+	if len(languages) == 0 {
+		exitWithError(errors.New("target language is required, set it with -t"))
+	}
+	path := flags.Arg(0)
+	if *out == "" {
+		ext := filepath.Ext(path)
+		*out = strings.TrimSuffix(path, ext) + ".translated" + ext
+	}
+
+	input, err := batch.Load(path, !*noHeader)
+	if err != nil {
+		exitWithError(err)
+	}
+	table := input
+	if !*restart {
+		if resumed, err := batch.Load(*out, !*noHeader); err == nil {
+			// the translations of an input edited since are stale
+			if !resumed.Extends(input) {
+				exitWithError(errors.New("Unable to resume from " + *out + "! Its rows differ from the ones of " + path + ", remove it or use -restart"))
+			}
+			table = resumed
+			fmt.Fprintln(os.Stderr, "Resuming from "+*out)
+		} else if !errors.Is(err, os.ErrNotExist) {
+			exitWithError(err)
+		}
+	}
+	table.Comma = batch.Comma(*out)
+
+	src, err := input.Column(*column)
+	if err != nil {
+		exitWithError(err)
+	}
+	// the column of every target, named after the source column when there is a header
+	columns := make([]int, len(languages))
+	width := input.Width()
+	for i, language := range languages {
+		if table.Header {
+			columns[i] = table.AddColumn(table.Records[0][src] + "_" + language)
+		} else {
+			columns[i] = width + i
+		}
+	}
+
+	// the rows left to translate for every target
+	pending := make([][]int, len(languages))
+	total := 0
+	for i := range languages {
+		for _, row := range table.Rows() {
+			if strings.TrimSpace(table.Get(row, src)) != "" && table.Get(row, columns[i]) == "" {
+				pending[i] = append(pending[i], row)
+			}
+		}
+		total += len(pending[i])
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	b := ff.newBackend(*rate)
+	p := &batchProgress{total: total, out: *out, table: table, last: time.Now(), terminal: term.IsTerminal(int(os.Stderr.Fd()))}
+	for i, language := range languages {
+		texts := make([]string, len(pending[i]))
+		for j, row := range pending[i] {
+			texts[j] = table.Get(row, src)
+		}
+		rows, col := pending[i], columns[i]
+		translator.TranslateEach(ctx, b, texts, *ff.source, language, *ff.engine, *workers, translator.FormatPlaceholders, func(j int, r translator.TextResult) {
+			if r.Err != nil && ctx.Err() != nil { // left empty to be translated when resuming
+				return
+			}
+			p.done(rows[j], col, r)
+		})
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	p.finish()
+
+	if ctx.Err() != nil {
+		exitWithError(errors.New("Interrupted! Run the same command again to resume from " + *out))
+	}
+	if p.failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// batchProgress records the translated cells, printing the progress and
+// saving the table at regular intervals
+type batchProgress struct {
+	mu         sync.Mutex
+	total      int
+	translated int
+	failed     int
+	out        string
+	table      *batch.Table
+	last       time.Time // when the table was last saved
+	terminal   bool      // whether stderr is a terminal, where the progress is kept on a single line
+}
+
+// done records the translation of a cell
+func (p *batchProgress) done(row, column int, r translator.TextResult) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if r.Err != nil {
+		p.failed++
+		p.clearLine()
+		fmt.Fprintln(os.Stderr, model.ErrorStyle.Render(fmt.Sprintf("Unable to translate row %d: %s", row+1, r.Err.Error())))
+	} else {
+		p.table.Set(row, column, r.Text)
+		p.translated++
+	}
+
+	saving := time.Since(p.last) >= checkpointInterval
+	if saving {
+		p.save()
+	}
+	if p.terminal || saving {
+		p.print()
+	}
+}
+
+// finish saves the table and prints the final progress
+func (p *batchProgress) finish() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.save()
+	p.print()
+	if p.terminal {
+		fmt.Fprintln(os.Stderr)
+	}
+}
+
+func (p *batchProgress) save() {
+	if err := p.table.Save(p.out); err != nil {
+		p.clearLine()
+		fmt.Fprintln(os.Stderr, model.ErrorStyle.Render("Unable to save "+p.out+": "+err.Error()))
+	}
+	p.last = time.Now()
+}
+
+func (p *batchProgress) print() {
+	p.clearLine()
+	fmt.Fprintf(os.Stderr, "%d of %d cells translated, %d failed, saved to %s", p.translated, p.total, p.failed, p.out)
+	if !p.terminal {
+		fmt.Fprintln(os.Stderr)
+	}
+}
+
+// clearLine erases the progress from the terminal
+func (p *batchProgress) clearLine() {
+	if p.terminal {
+		fmt.Fprint(os.Stderr, "\r\033[K")
+	}
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fedeztk/got/pkg/translator/cache"
+)
+
+func runCache(args []string) {
+	flags := flag.NewFlagSet("cache", flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), `Usage:
+  got cache [size]             print the size of the translations and audio caches
+  got cache list [cache]       list what is cached, newest first
+  got cache clear [cache]      remove what is cached
+
+cache could be translations or audio, both when omitted
+`)
+	}
+	flags.Parse(args)
+
+	action, kinds := "size", cache.Kinds()
+	if flags.NArg() > 0 {
+		action = flags.Arg(0)
+	}
+	if flags.NArg() > 1 {
+		k, err := cache.FindKind(flags.Arg(1))
+		if err != nil {
+			exitWithError(err)
+		}
+		kinds = []cache.Kind{k}
+	}
+
+	for _, k := range kinds {
+		var err error
+		switch action {
+		case "size":
+			err = printCacheSize(k)
+		case "list":
+			err = listCache(k)
+		case "clear":
+			if err = k.Clear(); err == nil {
+				fmt.Printf("%s cache cleared\n", k.Name)
+			}
+		default:
+			flags.Usage()
+			os.Exit(1)
+		}
+		if err != nil {
+			exitWithError(err)
+		}
+	}
+}
+
+func printCacheSize(k cache.Kind) error {
+	files, size, err := k.Size()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%-12s %5d files %10s  %s\n", k.Name, files, formatSize(size), k.Dir)
+	return nil
+}
+
+func listCache(k cache.Kind) error {
+	infos, err := k.List()
+	if err != nil {
+		return err
+	}
+	for _, i := range infos {
+		langs := i.Source + " → " + i.Target
+		if i.Language != "" {
+			langs = i.Language
+		}
+		fmt.Printf("%-12s %s %8s  %s %s: %s\n", k.Name, i.Time.Format("2006-01-02 15:04"), formatSize(i.Size), i.Backend, langs, strings.Join(strings.Fields(i.Text), " "))
+	}
+	return nil
+}
+
+// formatSize returns size in a human readable unit, e.g. 1.5MB
+func formatSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%dB", size)
+	}
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%cB", float64(size)/float64(div), "KMGTPE"[exp])
+}
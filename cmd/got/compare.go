@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fedeztk/got/internal/format"
+	"github.com/fedeztk/got/pkg/translator"
+)
+
+// compareOneShot prints the translations of text made by every engine of the
+// comma separated list engines. The instances given with -i are only used for
+// the backend given with -b
+func compareOneShot(ctx context.Context, engines, backend, instances string, useCache bool, text, source string, targets []string, outputFormat string) {
+	if len(targets) > 1 {
+		exitWithError(errors.New("engines can only be compared on a single target language"))
+	}
+	parsed, err := translator.ParseEngines(strings.Split(engines, ","))
+	if err != nil {
+		exitWithError(err)
+	}
+
+	backends := map[string]translator.Backend{}
+	for _, e := range parsed {
+		if _, ok := backends[e.Backend]; ok {
+			continue
+		}
+		if e.Backend == backend {
+			backends[e.Backend] = newOneShotBackend(e.Backend, instances, useCache, 0)
+		} else {
+			backends[e.Backend] = newOneShotBackend(e.Backend, "", useCache, 0)
+		}
+	}
+
+	comparisons := translator.Compare(ctx, backends, parsed, text, source, targets[0])
+	out, err := format.RenderComparisons(comparisons, outputFormat)
+	if err != nil {
+		exitWithError(err)
+	}
+	fmt.Println(out)
+
+	for _, c := range comparisons {
+		if c.Err == nil {
+			return
+		}
+	}
+	os.Exit(1) // every engine failed
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+)
+
+func runDetect(args []string) {
+	flags := flag.NewFlagSet("detect", flag.ExitOnError)
+	backend := flags.String(
+		"b",
+		"",
+		"backend could be lingvatranslate (default) or libretranslate",
+	)
+	instances := flags.String(
+		"i",
+		"",
+		"comma separated list of instance urls for the backend, tried in order",
+	)
+	flags.Parse(args)
+
+	text := strings.Join(flags.Args(), " ")
+	if text == "" {
+		fmt.Println("text is required to detect its language")
+		os.Exit(1)
+	}
+
+	b := newOneShotBackend(*backend, *instances, false, 0)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	lang, err := b.Detect(ctx, text)
+	if err != nil {
+		exitWithError(err)
+	}
+	fmt.Println(lang)
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+
+	"github.com/fedeztk/got/internal/document"
+	"github.com/fedeztk/got/internal/model"
+)
+
+func runDocument(args []string) {
+	flags := flag.NewFlagSet("doc", flag.ExitOnError)
+	ff := addFileFlags(flags, "auto", "language of the document, detected by default", "language to translate to, required")
+	format := flags.String(
+		"format",
+		"",
+		"markdown or html, told by the extension of the file by default",
+	)
+	out := flags.String(
+		"out",
+		"",
+		"file to write the translation to, - for the standard output, by default the\ninput file with the language in its name, e.g. README.md to README.it.md",
+	)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), `Usage:
+  got doc [flags] file.md|file.html|-
+
+Translates the prose of a Markdown or html document, leaving code blocks,
+inline code, urls, link destinations, tags and their attributes untouched.
+The document is read from the standard input and written to the standard
+output when the file is -.
+
+Flags:
+`)
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+	if flags.NArg() != 1 {
+		flags.Usage()
+		os.Exit(1)
+	}
+	if *ff.target == "" {
+		exitWithError(errors.New("target language is required, set it with -t"))
+	}
+	path := flags.Arg(0)
+	if *format == "" {
+		*format = "markdown"
+		if ext := strings.ToLower(filepath.Ext(path)); ext == ".html" || ext == ".htm" {
+			*format = "html"
+		}
+	}
+	if *out == "" {
+		*out = "-"
+		if path != "-" {
+			*out = translatedPath(path, *ff.source, *ff.target)
+		}
+	}
+
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		exitWithError(err)
+	}
+	var segments []document.Segment
+	switch *format {
+	case "markdown":
+		segments = document.ParseMarkdown(string(data))
+	case "html":
+		segments = document.ParseHTML(string(data))
+	default:
+		exitWithError(errors.New("document format not supported, please use markdown or html"))
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	b := ff.newBackend(0)
+	failed := 0
+	for _, err := range document.Translate(ctx, b, segments, *ff.source, *ff.target, *ff.engine) {
+		if err != nil {
+			fmt.Fprintln(os.Stderr, model.ErrorStyle.Render("Unable to translate a segment: "+err.Error()))
+			failed++
+		}
+	}
+	if ctx.Err() != nil {
+		exitWithError(ctx.Err())
+	}
+
+	translated := document.Join(segments)
+	if *out == "-" {
+		fmt.Print(translated)
+	} else {
+		if err := os.WriteFile(*out, []byte(translated), 0o644); err != nil {
+			exitWithError(err)
+		}
+		fmt.Fprintf(os.Stderr, "%d of %d segments translated, written to %s\n", document.Prose(segments)-failed, document.Prose(segments), *out)
+	}
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
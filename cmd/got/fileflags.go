@@ -0,0 +1,62 @@
+package main
+
+import (
+	"flag"
+
+	"github.com/fedeztk/got/pkg/translator"
+)
+
+// fileFlags are the flags shared by the subcommands translating files
+type fileFlags struct {
+	source    *string
+	target    *string
+	backend   *string
+	engine    *string
+	instances *string
+	noCache   *bool
+}
+
+// addFileFlags registers -s, -t, -b, -e, -i and -no-cache, source being the
+// default of -s. The help of -s and -t tells what the languages are
+func addFileFlags(flags *flag.FlagSet, source, sourceHelp, targetHelp string) *fileFlags {
+	return &fileFlags{
+		source: flags.String(
+			"s",
+			source,
+			sourceHelp,
+		),
+		target: flags.String(
+			"t",
+			"",
+			targetHelp,
+		),
+		backend: flags.String(
+			"b",
+			"",
+			"backend could be lingvatranslate (default), simplytranslate or libretranslate",
+		),
+		engine: flags.String(
+			"e",
+			"",
+			"engine of the simplytranslate backend, google by default",
+		),
+		instances: flags.String(
+			"i",
+			"",
+			"comma separated list of instance urls for the backend, tried in order",
+		),
+		noCache: flags.Bool(
+			"no-cache",
+			false,
+			"always ask the backend, bypassing the translations cache",
+		),
+	}
+}
+
+// newBackend returns the backend chosen by the flags, sending at most
+// rateLimit requests a second when positive. The engine falls back to the
+// default one of the backend
+func (f *fileFlags) newBackend(rateLimit float64) translator.Backend {
+	*f.engine = oneShotEngine(*f.backend, *f.engine)
+	return newOneShotBackend(*f.backend, *f.instances, !*f.noCache, rateLimit)
+}
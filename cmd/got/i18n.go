@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/fedeztk/got/internal/bundle"
+	"github.com/fedeztk/got/internal/model"
+)
+
+func runI18n(args []string) {
+	flags := flag.NewFlagSet("i18n", flag.ExitOnError)
+	ff := addFileFlags(flags, "en", "language of the source file", "language to translate to, required")
+	force := flags.Bool(
+		"force",
+		false,
+		"translate again the keys that already have a translation in the target file",
+	)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), `Usage:
+  got i18n [flags] source-file [target-file]
+
+Translates the strings of a JSON or YAML locale file and merges them into the
+target file, keeping the keys, the structure and the placeholders. Keys already
+translated in the target file are skipped unless -force is given.
+The target file defaults to the source path with the source language replaced
+by the target one, e.g. locales/en/common.json to locales/it/common.json.
+
+Flags:
+`)
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+	if flags.NArg() < 1 || flags.NArg() > 2 {
+		flags.Usage()
+		os.Exit(1)
+	}
+	if *ff.target == "" {
+		exitWithError(errors.New("target language is required, set it with -t"))
+	}
+	srcPath, dstPath := flags.Arg(0), flags.Arg(1)
+	if dstPath == "" {
+		var ok bool
+		if dstPath, ok = localePath(srcPath, *ff.source, *ff.target); !ok {
+			exitWithError(errors.New("Unable to tell the target file from " + srcPath + "! Pass it after the source file"))
+		}
+	}
+
+	src, err := bundle.Load(srcPath)
+	if err != nil {
+		exitWithError(err)
+	}
+	src.RenameRoot(*ff.source, *ff.target)
+	dst, err := bundle.Load(dstPath)
+	if errors.Is(err, os.ErrNotExist) {
+		dst, err = bundle.New(bundle.IsJSON(dstPath)), nil
+	}
+	if err != nil {
+		exitWithError(err)
+	}
+
+	leaves := []bundle.Leaf{}
+	texts := []string{}
+	for _, leaf := range src.Strings() {
+		if translated, ok := dst.Get(leaf.Path); ok && translated != "" && !*force {
+			continue
+		}
+		leaves = append(leaves, leaf)
+		texts = append(texts, leaf.Value)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	b := ff.newBackend(0)
+	results := bundle.Translate(ctx, b, texts, *ff.source, *ff.target, *ff.engine)
+	if ctx.Err() != nil {
+		exitWithError(ctx.Err())
+	}
+
+	translations := map[string]string{}
+	for i, r := range results {
+		if r.Err != nil {
+			fmt.Fprintln(os.Stderr, model.ErrorStyle.Render("Unable to translate "+strings.Join(leaves[i].Path, ".")+": "+r.Err.Error()))
+			continue
+		}
+		translations[bundle.Key(leaves[i].Path)] = r.Text
+	}
+	dst.Merge(src, translations)
+	if err := dst.Save(dstPath); err != nil {
+		exitWithError(err)
+	}
+	fmt.Fprintf(os.Stderr, "%d of %d strings translated, written to %s\n", len(translations), len(leaves), dstPath)
+	if len(translations) < len(leaves) {
+		os.Exit(1)
+	}
+}
@@ -1,22 +1,51 @@
 package main
 
 import (
+	"context"
 	_ "embed"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"os"
+	"os/signal"
 	"strings"
 
+	"github.com/charmbracelet/lipgloss"
 	"github.com/fedeztk/got/internal/config"
+	"github.com/fedeztk/got/internal/format"
 	"github.com/fedeztk/got/internal/model"
 	"github.com/fedeztk/got/pkg/translator"
+	"github.com/fedeztk/got/pkg/translator/cache"
+	"github.com/fedeztk/got/pkg/translator/glossary"
+	"github.com/muesli/termenv"
+	"golang.org/x/term"
 )
 
 //go:generate ./get_version.sh
 //go:embed .version
 var gotVersion string
 
+// subcommands are one shot modes with their own flags, invoked as got <name>
+var subcommands = map[string]func(args []string){
+	"detect":     runDetect,
+	"cache":      runCache,
+	"phrasebook": runPhrasebook,
+	"po":         runPo,
+	"i18n":       runI18n,
+	"subtitles":  runSubtitles,
+	"doc":        runDocument,
+	"batch":      runBatch,
+}
+
 func main() {
+	if len(os.Args) > 1 {
+		if run, ok := subcommands[os.Args[1]]; ok {
+			run(os.Args[2:])
+			os.Exit(0)
+		}
+	}
+
 	showVersion := flag.Bool(
 		"v",
 		false,
@@ -25,7 +54,12 @@ func main() {
 	oneShot := flag.Bool(
 		"o",
 		false,
-		"one shot mode, requires -s and -t",
+		"one shot mode, requires -s and -t\ntranslates the arguments, the file given with -f or the standard input",
+	)
+	file := flag.String(
+		"f",
+		"",
+		"file to translate in one shot mode",
 	)
 	source := flag.String(
 		"s",
@@ -35,7 +69,7 @@ func main() {
 	target := flag.String(
 		"t",
 		"",
-		"language to translate to",
+		"language to translate to, comma separated list to translate to several languages in one shot mode",
 	)
 	engine := flag.String(
 		"e",
@@ -47,8 +81,57 @@ Deepl is not supported yet and defaults to google`,
 	backend := flag.String(
 		"b",
 		"",
-		"backend could be lingvatranslate (default) or simplytranslate",
+		"backend could be lingvatranslate (default), simplytranslate or libretranslate",
+	)
+	instances := flag.String(
+		"i",
+		"",
+		`comma separated list of instance urls for the backend, tried in order
+overrides the instances section of the config file`,
+	)
+	outputFormat := flag.String(
+		"format",
+		"pretty",
+		"output format of one shot mode, could be: "+strings.Join(format.Formats, ", "),
+	)
+	noCache := flag.Bool(
+		"no-cache",
+		false,
+		"always ask the backend, bypassing the translations cache",
+	)
+	tts := flag.String(
+		"tts",
+		"",
+		"speak the text in one shot mode, could be: "+strings.Join(ttsSides, ", "),
 	)
+	ttsOut := flag.String(
+		"tts-out",
+		"",
+		"write the audio of the text chosen with -tts (the translation by default)\nto this mp3 file in one shot mode, or wav with a local speech engine",
+	)
+	compare := flag.String(
+		"compare",
+		"",
+		`compare the translations of several engines in one shot mode,
+comma separated list of backend:engine, e.g. simplytranslate:google,simplytranslate:reverso,lingvatranslate`,
+	)
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), `Usage:
+  got [flags] [text]
+  got -o -s source -t target [-f file] [text]
+  got detect [flags] text  print the code of the language text is written in
+  got cache [size|list|clear] [translations|audio]  manage the caches
+  got phrasebook [list|export] [flags]  list or export to Anki the starred translations
+  got po [flags] file.po   translate the untranslated messages of a gettext catalog
+  got i18n [flags] source-file [target-file]  translate a JSON or YAML locale file
+  got subtitles [flags] file.srt|file.vtt  translate SubRip or WebVTT subtitles
+  got doc [flags] file.md|file.html  translate the prose of a Markdown or html document
+  got batch [flags] file.csv|file.tsv  translate a column of a table into one column per language
+
+Flags:
+`)
+		flag.PrintDefaults()
+	}
 	flag.Parse()
 
 	switch {
@@ -56,29 +139,67 @@ Deepl is not supported yet and defaults to google`,
 		fmt.Println(gotVersion)
 
 	case *oneShot:
-		if *source == "" || *target == "" {
+		targets := translator.ParseTargets(*target)
+		if *source == "" || len(targets) == 0 {
 			fmt.Println("source and target are required in one shot mode")
 			os.Exit(1)
 		}
-		if *engine == "" {
-			*engine = "google"
+		*engine = oneShotEngine(*backend, *engine)
+
+		if err := format.Check(*outputFormat); err != nil {
+			exitWithError(err)
 		}
-		if *backend == "" {
-			*backend = "lingvatranslate"
+		play := *tts != ""
+		if !play {
+			*tts = "translation"
+		}
+		if err := checkTTSSide(*tts); err != nil {
+			exitWithError(err)
+		}
+		if !term.IsTerminal(int(os.Stdout.Fd())) {
+			lipgloss.SetColorProfile(termenv.Ascii)
 		}
 
-		backend, err := translator.NewBackend(*backend)
+		text, err := readOneShotText(flag.Args(), *file)
 		if err != nil {
-			fmt.Println(model.ErrorStyle.Render(err.Error()))
-			os.Exit(1)
+			exitWithError(err)
 		}
-		response, err := backend.Translate(strings.Join(flag.Args(), " "), *source, *target, *engine)
 
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		if *compare != "" {
+			compareOneShot(ctx, *compare, *backend, *instances, !*noCache, text, *source, targets, *outputFormat)
+			break
+		}
+
+		backend := newOneShotBackend(*backend, *instances, !*noCache, 0)
+		results := translator.TranslateTargets(ctx, backend, text, *source, targets, *engine, translator.MaxWorkers)
+
+		var out string
+		if len(results) == 1 {
+			if results[0].Err != nil {
+				exitWithError(results[0].Err)
+			}
+			out, err = format.Render(results[0].Response, *outputFormat)
+		} else {
+			out, err = format.RenderTargets(results, *outputFormat)
+		}
 		if err != nil {
-			fmt.Println(model.ErrorStyle.Render(err.Error()))
-			os.Exit(1)
+			exitWithError(err)
+		}
+		fmt.Println(out)
+
+		// with several targets only the first one is spoken
+		if first := results[0]; (play || *ttsOut != "") && first.Err == nil {
+			if err := speakOneShot(ctx, backend, first.Response, *tts, play, *ttsOut, text, *source, first.Target); err != nil {
+				exitWithError(err)
+			}
+		}
+		for _, r := range results {
+			if r.Err != nil {
+				os.Exit(1)
+			}
 		}
-		fmt.Println(response.PrettyPrint())
 
 	default:
 		conf := config.NewConfig()
@@ -88,11 +209,87 @@ Deepl is not supported yet and defaults to google`,
 		if *backend != "" {
 			conf.SetBackend(*backend)
 		}
-		if conf.Backend() == "lingvatranslate" {
-			conf.SetEngine("google")
+		if *instances != "" {
+			conf.SetInstances(*instances)
+		}
+		if *noCache {
+			conf.DisableCache()
+		}
+		// fall back to the default engine of the backend
+		if translator.CheckEngine(conf.Backend(), conf.Engine()) != nil && len(translator.Engines[conf.Backend()]) > 0 {
+			conf.SetEngine(translator.Engines[conf.Backend()][0])
 		}
 		model.Run(conf)
 	}
 
 	os.Exit(0)
 }
+
+// newOneShotBackend returns the backend to use in one shot modes, defaulting
+// to lingvatranslate and enforcing the glossaries, with at most rateLimit
+// requests a second when positive. Settings not given from the command line
+// are read from the config file, if any
+func newOneShotBackend(backend, instances string, useCache bool, rateLimit float64) translator.Backend {
+	if backend == "" {
+		backend = "lingvatranslate"
+	}
+
+	conf := config.ReadConfig()
+	conf.SetBackend(backend)
+	if instances != "" {
+		conf.SetInstances(instances)
+	}
+	b, err := translator.NewBackend(conf.Backend(), translator.Options{
+		APIKey:    conf.APIKey(),
+		Instances: conf.Instances(),
+		Timeout:   conf.Timeout(),
+	})
+	if err != nil {
+		exitWithError(err)
+	}
+	b = translator.RateLimit(b, rateLimit)
+	if useCache {
+		b = cache.New(b, conf.Backend(), cache.Options{
+			TTL:          conf.CacheTTL(),
+			MaxSize:      conf.CacheMaxSize(),
+			AudioMaxSize: conf.AudioCacheMaxSize(),
+		})
+	}
+	return glossary.NewBackend(b, glossary.NewSet(conf.Glossaries()))
+}
+
+// oneShotEngine returns engine, or the default engine of backend when it is
+// empty or not supported by backend, like the tui does, so that both modes
+// share the cached translations
+func oneShotEngine(backend, engine string) string {
+	if backend == "" {
+		backend = "lingvatranslate"
+	}
+	if translator.CheckEngine(backend, engine) != nil && len(translator.Engines[backend]) > 0 {
+		return translator.Engines[backend][0]
+	}
+	return engine
+}
+
+// readOneShotText returns the text to translate, read from the file given with
+// -f, the arguments or the standard input when it is piped, in this order
+func readOneShotText(args []string, file string) (string, error) {
+	if file != "" {
+		data, err := os.ReadFile(file)
+		return string(data), err
+	}
+	if len(args) > 0 {
+		return strings.Join(args, " "), nil
+	}
+
+	if stat, err := os.Stdin.Stat(); err == nil && stat.Mode()&os.ModeCharDevice == 0 {
+		data, err := io.ReadAll(os.Stdin)
+		return string(data), err
+	}
+	return "", errors.New("nothing to translate, pass the text as argument, with -f or from the standard input")
+}
+
+func exitWithError(err error) {
+	fmt.Fprintln(os.Stderr, model.ErrorStyle.Render(err.Error()))
+	os.Exit(1)
+}
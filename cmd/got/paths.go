@@ -0,0 +1,56 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/fedeztk/got/pkg/translator/utils"
+)
+
+// localePath returns path with the directories and the dot separated parts of
+// the file name equal to the source language replaced by target, e.g.
+// locales/en/app.json, en.yml or app.en.json
+func localePath(path, source, target string) (string, bool) {
+	parts := strings.Split(filepath.ToSlash(path), "/")
+	replaced := false
+	for i, part := range parts {
+		names := strings.Split(part, ".")
+		for j, name := range names {
+			// the extension is never a language
+			if strings.EqualFold(name, source) && (i < len(parts)-1 || j < len(names)-1) {
+				names[j] = target
+				replaced = true
+			}
+		}
+		parts[i] = strings.Join(names, ".")
+	}
+	return filepath.FromSlash(strings.Join(parts, "/")), replaced
+}
+
+// translatedPath returns path with the source language replaced by target, or
+// target added before the extension when path doesn't tell the language.
+// A detected source is unknown, so a language code before the extension is
+// replaced whatever it is, e.g. talk.en.srt to talk.it.srt
+func translatedPath(path, source, target string) string {
+	if translated, ok := localePath(path, source, target); ok {
+		return translated
+	}
+	ext := filepath.Ext(path)
+	name := strings.TrimSuffix(path, ext)
+	if lang := filepath.Ext(name); lang != "" && lang != filepath.Base(name) && isLanguage(lang[1:]) {
+		if translated := strings.TrimSuffix(name, lang) + "." + target + ext; translated != path {
+			return translated
+		}
+	}
+	return name + "." + target + ext
+}
+
+// isLanguage reports whether code is the code of a language
+func isLanguage(code string) bool {
+	for c := range utils.GetAllLanguages() {
+		if strings.EqualFold(c, code) {
+			return true
+		}
+	}
+	return false
+}
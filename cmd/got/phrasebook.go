@@ -0,0 +1,109 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fedeztk/got/internal/config"
+	"github.com/fedeztk/got/internal/model"
+	"github.com/fedeztk/got/internal/phrasebook"
+	"github.com/fedeztk/got/pkg/translator/cache"
+)
+
+// exportFormats are the separators of the phrasebook export formats
+var exportFormats = map[string]rune{"csv": ',', "tsv": '\t'}
+
+func runPhrasebook(args []string) {
+	flags := flag.NewFlagSet("phrasebook", flag.ExitOnError)
+	exportFormat := flags.String(
+		"format",
+		"csv",
+		"format of the export, could be: csv, tsv",
+	)
+	out := flags.String(
+		"out",
+		"",
+		"file to export to, the standard output when omitted",
+	)
+	media := flags.String(
+		"media",
+		"",
+		`directory to copy the cached text to speech audio of the translations to,
+attached to the notes. Copy its content to the collection.media folder of Anki`,
+	)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), `Usage:
+  got phrasebook [list]               list the starred translations, newest first
+  got phrasebook export [flags]       export them as notes Anki can import
+
+Flags:
+`)
+		flags.PrintDefaults()
+	}
+
+	action := "list"
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		action, args = args[0], args[1:]
+	}
+	flags.Parse(args)
+
+	store := phrasebook.NewStore(config.ReadConfig().Phrasebook())
+	entries, err := store.Entries()
+	if err != nil {
+		exitWithError(err)
+	}
+
+	switch action {
+	case "list":
+		for _, e := range entries {
+			fmt.Printf("%s %s → %s: %s = %s\n", e.Time.Format("2006-01-02 15:04"), e.Source, e.Target, e.Query, e.Result.Translation)
+		}
+	case "export":
+		sep, ok := exportFormats[*exportFormat]
+		if !ok {
+			exitWithError(errors.New("export format not supported, please use one of the following: csv, tsv"))
+		}
+		if err := exportPhrasebook(entries, sep, *out, *media); err != nil {
+			exitWithError(err)
+		}
+	default:
+		flags.Usage()
+		os.Exit(1)
+	}
+}
+
+// exportPhrasebook writes entries as Anki notes to out, copying the cached
+// audio of their translation to media when it is not empty
+func exportPhrasebook(entries []phrasebook.Entry, sep rune, out, media string) error {
+	var w io.Writer = os.Stdout
+	if out != "" {
+		f, err := os.Create(out)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	var sound func(phrasebook.Entry) string
+	if media != "" {
+		sound = func(e phrasebook.Entry) string {
+			audio, ok := cache.CachedAudio(e.Backend, e.Target, e.Result.Translation)
+			if !ok {
+				return ""
+			}
+			path, err := model.SaveAudio(media, e.Target, e.Result.Translation, audio)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, model.ErrorStyle.Render("Unable to save audio: "+err.Error()))
+				return ""
+			}
+			return filepath.Base(path)
+		}
+	}
+	return phrasebook.ExportAnki(w, entries, sep, sound)
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+
+	"github.com/fedeztk/got/internal/model"
+	"github.com/fedeztk/got/internal/po"
+	"github.com/fedeztk/got/pkg/translator"
+)
+
+func runPo(args []string) {
+	flags := flag.NewFlagSet("po", flag.ExitOnError)
+	ff := addFileFlags(flags, "en", "language of the msgids", "language to translate to, read from the Language header when omitted")
+	out := flags.String(
+		"out",
+		"",
+		"file to write the catalog to, the translated file itself by default,\n<target>.po next to it for templates (.pot)",
+	)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), `Usage:
+  got po [flags] file.po|file.pot
+
+Translates the untranslated messages of a gettext catalog and marks them fuzzy,
+the rest of the catalog is left as it is.
+
+Flags:
+`)
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+	if flags.NArg() != 1 {
+		flags.Usage()
+		os.Exit(1)
+	}
+	path := flags.Arg(0)
+
+	f, err := os.Open(path)
+	if err != nil {
+		exitWithError(err)
+	}
+	catalog, err := po.Parse(f)
+	f.Close()
+	if err != nil {
+		exitWithError(errors.New("Unable to parse " + path + ": " + err.Error()))
+	}
+
+	if *ff.target == "" {
+		*ff.target = catalog.HeaderField("Language")
+	}
+	if *ff.target == "" {
+		exitWithError(errors.New("target language is required, set it with -t or in the Language header of the catalog"))
+	}
+	if *out == "" {
+		*out = path
+		if strings.EqualFold(filepath.Ext(path), ".pot") {
+			*out = filepath.Join(filepath.Dir(path), *ff.target+".po")
+		}
+	}
+
+	// the singular and the plural of every untranslated message, in order
+	untranslated := []*po.Entry{}
+	texts := []string{}
+	for _, e := range catalog.Entries {
+		if !e.IsTranslated() {
+			untranslated = append(untranslated, e)
+			texts = append(texts, e.ID(), e.Plural())
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	b := ff.newBackend(0)
+	results := translator.TranslateTexts(ctx, b, texts, *ff.source, *ff.target, *ff.engine, translator.MaxWorkers, translator.FormatPlaceholders)
+	if ctx.Err() != nil {
+		exitWithError(ctx.Err())
+	}
+
+	translated := 0
+	for i, e := range untranslated {
+		singular, plural := results[2*i], results[2*i+1]
+		if err := firstError(singular.Err, plural.Err); err != nil {
+			fmt.Fprintln(os.Stderr, model.ErrorStyle.Render("Unable to translate "+fmt.Sprintf("%q", e.ID())+": "+err.Error()))
+			continue
+		}
+		e.Translate(singular.Text, plural.Text, catalog.NPlurals())
+		e.AddFlag("fuzzy")
+		translated++
+	}
+	if catalog.HeaderField("Language") == "" {
+		catalog.SetHeaderField("Language", *ff.target)
+	}
+
+	var buf bytes.Buffer
+	if err := catalog.Write(&buf); err != nil {
+		exitWithError(err)
+	}
+	if err := os.WriteFile(*out, buf.Bytes(), 0o644); err != nil {
+		exitWithError(err)
+	}
+	fmt.Fprintf(os.Stderr, "%d of %d untranslated messages translated and marked fuzzy, written to %s\n", translated, len(untranslated), *out)
+	if translated < len(untranslated) {
+		os.Exit(1)
+	}
+}
+
+func firstError(errs ...error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"github.com/fedeztk/got/internal/model"
+	"github.com/fedeztk/got/internal/subtitle"
+)
+
+func runSubtitles(args []string) {
+	flags := flag.NewFlagSet("subtitles", flag.ExitOnError)
+	ff := addFileFlags(flags, "auto", "language of the subtitles, detected by default", "language to translate to, required")
+	out := flags.String(
+		"out",
+		"",
+		"file to write the subtitles to, by default the input file with the\nlanguage in its name, e.g. talk.srt or talk.en.srt to talk.it.srt",
+	)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), `Usage:
+  got subtitles [flags] file.srt|file.vtt
+
+Translates the cues of SubRip or WebVTT subtitles, keeping indexes, timestamps,
+styling tags and line breaks. Consecutive cues are translated together to
+send as few requests as possible.
+
+Flags:
+`)
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+	if flags.NArg() != 1 {
+		flags.Usage()
+		os.Exit(1)
+	}
+	if *ff.target == "" {
+		exitWithError(errors.New("target language is required, set it with -t"))
+	}
+	path := flags.Arg(0)
+	if *out == "" {
+		*out = translatedPath(path, *ff.source, *ff.target)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		exitWithError(err)
+	}
+	subs, err := subtitle.Parse(f)
+	f.Close()
+	if err != nil {
+		exitWithError(errors.New("Unable to parse " + path + ": " + err.Error()))
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	b := ff.newBackend(0)
+	errs := subs.Translate(ctx, b, *ff.source, *ff.target, *ff.engine)
+	if ctx.Err() != nil {
+		exitWithError(ctx.Err())
+	}
+
+	failed := 0
+	for i, cue := range subs.Cues() {
+		if errs[i] != nil {
+			fmt.Fprintln(os.Stderr, model.ErrorStyle.Render("Unable to translate the cue at "+cue.Timing+": "+errs[i].Error()))
+			failed++
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := subs.Write(&buf); err != nil {
+		exitWithError(err)
+	}
+	if err := os.WriteFile(*out, buf.Bytes(), 0o644); err != nil {
+		exitWithError(err)
+	}
+	fmt.Fprintf(os.Stderr, "%d of %d cues translated, written to %s\n", len(errs)-failed, len(errs), *out)
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fedeztk/got/internal/config"
+	"github.com/fedeztk/got/internal/model"
+	"github.com/fedeztk/got/pkg/translator"
+	"github.com/fedeztk/got/pkg/translator/utils"
+	"github.com/fedeztk/got/pkg/tts"
+)
+
+// ttsSides are the texts that can be spoken in one shot mode
+var ttsSides = []string{"translation", "source"}
+
+func checkTTSSide(side string) error {
+	for _, s := range ttsSides {
+		if s == side {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown text to speech side %q, could be: %s", side, strings.Join(ttsSides, ", "))
+}
+
+// speakOneShot fetches the audio of either the translation or the source text,
+// then plays it if play is set and writes it to out if not empty
+func speakOneShot(ctx context.Context, b translator.Backend, r utils.BackendResponse, side string, play bool, out, text, source, target string) error {
+	speech, err := tts.WithFallback(b, config.ReadConfig().TTSEngines())
+	if err != nil {
+		return err
+	}
+
+	spoken, lang := r.ShortTranslatedText(), target
+	if side == "source" {
+		spoken, lang = strings.TrimSpace(text), source
+		if lang == utils.AutoDetect.Code {
+			lang = r.DetectedLanguage()
+		}
+		if lang == "" {
+			detected, err := b.Detect(ctx, spoken)
+			if err != nil {
+				return err
+			}
+			lang = detected
+		}
+	}
+
+	audio, err := speech.TextToSpeech(ctx, spoken, lang)
+	if err != nil {
+		return err
+	}
+	if out != "" {
+		// a local speech engine answers with wav instead of mp3
+		if ext := model.AudioExtension(audio); !strings.EqualFold(filepath.Ext(out), ext) {
+			out = strings.TrimSuffix(out, filepath.Ext(out)) + ext
+			fmt.Fprintln(os.Stderr, "Audio saved as "+strings.TrimPrefix(ext, ".")+" to "+out)
+		}
+		if err := ioutil.WriteFile(out, audio, 0o644); err != nil {
+			return err
+		}
+	}
+	if play {
+		return model.PlayAudio(audio)
+	}
+	return nil
+}
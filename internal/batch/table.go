@@ -0,0 +1,183 @@
+// Package batch reads and writes the CSV and TSV spreadsheets translated by
+// got batch, one column into a new column per target language
+package batch
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Table is a spreadsheet, its first record is the header when it has one
+type Table struct {
+	Records [][]string
+	Header  bool
+	Comma   rune
+}
+
+// Comma returns the separator of the file at path: a tab for .tsv and .tab
+// files, a comma otherwise
+func Comma(path string) rune {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".tsv", ".tab":
+		return '\t'
+	}
+	return ','
+}
+
+// Read reads a table, records may have different lengths
+func Read(r io.Reader, comma rune, header bool) (*Table, error) {
+	reader := csv.NewReader(r)
+	reader.Comma = comma
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = comma == '\t'
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if header && len(records) == 0 {
+		return nil, errors.New("the header is missing")
+	}
+	return &Table{Records: records, Header: header, Comma: comma}, nil
+}
+
+// Load reads the table at path
+func Load(path string, header bool) (*Table, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	t, err := Read(f, Comma(path), header)
+	if err != nil {
+		return nil, errors.New("Unable to parse " + path + ": " + err.Error())
+	}
+	return t, nil
+}
+
+// Write writes t, every record padded to the width of the widest
+func (t *Table) Write(w io.Writer) error {
+	width := t.Width()
+	writer := csv.NewWriter(w)
+	writer.Comma = t.Comma
+	for _, record := range t.Records {
+		for len(record) < width {
+			record = append(record, "")
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// Save writes t to path through a temporary file, so that an interruption
+// never leaves it half written
+func (t *Table) Save(path string) error {
+	var buf bytes.Buffer
+	if err := t.Write(&buf); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, buf.Bytes(), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// Width returns the number of columns
+func (t *Table) Width() int {
+	width := 0
+	for _, record := range t.Records {
+		if len(record) > width {
+			width = len(record)
+		}
+	}
+	return width
+}
+
+// Rows returns the indexes of the records that are not the header
+func (t *Table) Rows() []int {
+	rows := []int{}
+	for i := range t.Records {
+		if i > 0 || !t.Header {
+			rows = append(rows, i)
+		}
+	}
+	return rows
+}
+
+// Column returns the index of the column with the given name in the header,
+// or with the given 1-based number
+func (t *Table) Column(column string) (int, error) {
+	if t.Header {
+		for i, name := range t.Records[0] {
+			if strings.EqualFold(strings.TrimSpace(name), column) {
+				return i, nil
+			}
+		}
+	}
+	if n, err := strconv.Atoi(column); err == nil && n >= 1 && n <= t.Width() {
+		return n - 1, nil
+	}
+	return 0, errors.New("column " + column + " not found")
+}
+
+// AddColumn returns the index of the column named name, adding it after the
+// last one if the table doesn't have it. Tables without header only add columns
+func (t *Table) AddColumn(name string) int {
+	if t.Header {
+		for i, existing := range t.Records[0] {
+			if existing == name {
+				return i
+			}
+		}
+	}
+	width := t.Width()
+	if t.Header {
+		for len(t.Records[0]) < width {
+			t.Records[0] = append(t.Records[0], "")
+		}
+		t.Records[0] = append(t.Records[0], name)
+	}
+	return width
+}
+
+// Get returns a cell, empty if the record is shorter
+func (t *Table) Get(row, column int) string {
+	if column < len(t.Records[row]) {
+		return t.Records[row][column]
+	}
+	return ""
+}
+
+// Set sets a cell, padding the record if needed
+func (t *Table) Set(row, column int, value string) {
+	for len(t.Records[row]) <= column {
+		t.Records[row] = append(t.Records[row], "")
+	}
+	t.Records[row][column] = value
+}
+
+// Extends reports whether t has the records of input, maybe with more columns,
+// like a translation of input saved before being interrupted
+func (t *Table) Extends(input *Table) bool {
+	if len(t.Records) != len(input.Records) {
+		return false
+	}
+	width := input.Width()
+	for row := range input.Records {
+		for column := 0; column < width; column++ {
+			if t.Get(row, column) != input.Get(row, column) {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,71 @@
+package batch
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTable(t *testing.T) {
+	table, err := Read(strings.NewReader("key\ttext\nk1\tHello \"world\"\nk2\n"), '\t', true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rows := table.Rows(); len(rows) != 2 || rows[0] != 1 {
+		t.Errorf("got rows %v, want [1 2]", rows)
+	}
+	for _, column := range []string{"Text", "2"} {
+		if i, err := table.Column(column); err != nil || i != 1 {
+			t.Errorf("got column %d (%v) for %s, want 1", i, err, column)
+		}
+	}
+	if _, err := table.Column("3"); err == nil {
+		t.Error("expected an error for a missing column")
+	}
+
+	it := table.AddColumn("text_it")
+	if again := table.AddColumn("text_it"); again != it || it != 2 {
+		t.Errorf("got columns %d and %d, want 2", it, again)
+	}
+	table.Set(1, it, "Ciao \"mondo\"")
+	if table.Get(2, 1) != "" || table.Get(1, it) != "Ciao \"mondo\"" {
+		t.Errorf("unexpected cells %q", table.Records)
+	}
+
+	builder := strings.Builder{}
+	if err := table.Write(&builder); err != nil {
+		t.Fatal(err)
+	}
+	want := "key\ttext\ttext_it\nk1\t\"Hello \"\"world\"\"\"\t\"Ciao \"\"mondo\"\"\"\nk2\t\t\n"
+	if got := builder.String(); got != want {
+		t.Errorf("got\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestComma(t *testing.T) {
+	if Comma("a.TSV") != '\t' || Comma("a.csv") != ',' {
+		t.Error("unexpected separators")
+	}
+}
+
+func TestExtends(t *testing.T) {
+	input, _ := Read(strings.NewReader("key,text\nk1,Hello\nk2\n"), ',', true)
+	testCases := []struct {
+		output string
+		want   bool
+	}{
+		{"key,text,text_it\nk1,Hello,Ciao\nk2,,\n", true},
+		{"key,text\nk1,Hello\nk2,\n", true},
+		{"key,text,text_it\nk1,Hi,Ciao\nk2,,\n", false},
+		{"key,text,text_it\nk2,,\nk1,Hello,Ciao\n", false},
+		{"key,text,text_it\nk1,Hello,Ciao\n", false},
+	}
+	for _, tc := range testCases {
+		output, err := Read(strings.NewReader(tc.output), ',', true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := output.Extends(input); got != tc.want {
+			t.Errorf("Extends() of %q = %v, want %v", tc.output, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,401 @@
+// Package bundle reads and writes the JSON and YAML locale files of i18n
+// libraries like i18next, vue-i18n or Rails. The files are kept as yaml nodes,
+// JSON being a subset of YAML, so that keys keep their order and YAML comments survive
+package bundle
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/fedeztk/got/pkg/translator"
+	"gopkg.in/yaml.v3"
+)
+
+// Placeholders matches what must reach the translation unchanged besides the
+// format placeholders: i18next nesting like $t(key) and vue-i18n linked
+// messages like @:key
+var Placeholders = regexp.MustCompile(translator.FormatPlaceholders.String() + "|" + strings.Join([]string{
+	`\$t\([^)]*\)`,
+	`@(?:\.\w+)?:[\w.-]+`,
+}, "|"))
+
+// Translate translates texts to target keeping their placeholders and the
+// syntax of ICU messages, of which only the text of the branches is translated
+func Translate(ctx context.Context, b translator.Backend, texts []string, source, target, engine string) []translator.TextResult {
+	var plain, icu []int
+	for i, text := range texts {
+		if icuHeader.MatchString(text) {
+			icu = append(icu, i)
+		} else {
+			plain = append(plain, i)
+		}
+	}
+	results := make([]translator.TextResult, len(texts))
+
+	selected := make([]string, len(plain))
+	for i, index := range plain {
+		selected[i] = texts[index]
+	}
+	for i, r := range translator.TranslateTexts(ctx, b, selected, source, target, engine, translator.MaxWorkers, Placeholders) {
+		results[plain[i]] = r
+	}
+
+	// ICU messages are masked beforehand, their syntax doesn't fit a regexp
+	selected = make([]string, len(icu))
+	masks := make([]*translator.Placeholders, len(icu))
+	for i, index := range icu {
+		masks[i] = &translator.Placeholders{}
+		selected[i] = maskICU(texts[index], masks[i])
+	}
+	for i, r := range translator.TranslateTexts(ctx, b, selected, source, target, engine, translator.MaxWorkers, nil) {
+		r.Text = masks[i].Restore(r.Text)
+		results[icu[i]] = r
+	}
+	return results
+}
+
+// Bundle is a locale file
+type Bundle struct {
+	root   *yaml.Node // document node
+	json   bool
+	indent string
+}
+
+// Leaf is a string of a bundle with the keys leading to it, the indexes of
+// arrays as decimal numbers
+type Leaf struct {
+	Path  []string
+	Value string
+}
+
+// IsJSON reports whether the file at path is a JSON bundle, it is YAML otherwise
+func IsJSON(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".json")
+}
+
+// New returns an empty bundle, in JSON if json is set
+func New(json bool) *Bundle {
+	return &Bundle{
+		root:   &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}},
+		json:   json,
+		indent: "  ",
+	}
+}
+
+// Load reads the bundle at path, its format is told by the extension
+func Load(path string) (*Bundle, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	b, err := Parse(data, IsJSON(path))
+	if err != nil {
+		return nil, errors.New("Unable to parse " + path + ": " + err.Error())
+	}
+	return b, nil
+}
+
+var indentation = regexp.MustCompile(`(?m)^[ \t]+`)
+
+// Parse reads a bundle, in JSON if json is set
+func Parse(data []byte, json bool) (*Bundle, error) {
+	b := New(json)
+	if len(bytes.TrimSpace(data)) == 0 {
+		return b, nil
+	}
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, err
+	}
+	if len(root.Content) == 0 || root.Content[0].Kind != yaml.MappingNode {
+		return nil, errors.New("the top level must be an object")
+	}
+	b.root = &root
+	if found := indentation.Find(data); found != nil {
+		b.indent = string(found)
+	}
+	return b, nil
+}
+
+// top returns the top level mapping
+func (b *Bundle) top() *yaml.Node {
+	return b.root.Content[0]
+}
+
+// Strings returns the string leaves, in the order of the file
+func (b *Bundle) Strings() []Leaf {
+	leaves := []Leaf{}
+	var walk func(n *yaml.Node, path []string)
+	walk = func(n *yaml.Node, path []string) {
+		switch n.Kind {
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(n.Content); i += 2 {
+				walk(n.Content[i+1], append(path[:len(path):len(path)], n.Content[i].Value))
+			}
+		case yaml.SequenceNode:
+			for i, item := range n.Content {
+				walk(item, append(path[:len(path):len(path)], strconv.Itoa(i)))
+			}
+		case yaml.ScalarNode:
+			if isString(n) {
+				leaves = append(leaves, Leaf{Path: path, Value: n.Value})
+			}
+		}
+	}
+	walk(b.top(), nil)
+	return leaves
+}
+
+// Get returns the string at path
+func (b *Bundle) Get(path []string) (string, bool) {
+	n := b.top()
+	for _, key := range path {
+		if n = child(n, key); n == nil {
+			return "", false
+		}
+	}
+	if !isString(n) {
+		return "", false
+	}
+	return n.Value, true
+}
+
+// RenameRoot renames the only top level key when it is from, as Rails bundles
+// keep everything under the locale, e.g. en: to it:
+func (b *Bundle) RenameRoot(from, to string) {
+	top := b.top()
+	if len(top.Content) == 2 && strings.EqualFold(top.Content[0].Value, from) {
+		top.Content[0].Value = to
+	}
+}
+
+// Merge adds the strings of src to b, translated by translations keyed by
+// Key(path). Strings without a translation keep the value they have in b and
+// are not added if b doesn't have them, the other values of src missing in b
+// are copied. An empty bundle takes the indentation of src
+func (b *Bundle) Merge(src *Bundle, translations map[string]string) {
+	if len(b.top().Content) == 0 {
+		b.indent = src.indent
+	}
+	m := merger{translations: translations, keepStyle: !src.json && !b.json}
+	b.root.Content[0] = m.merge(b.top(), src.top(), nil)
+}
+
+// Key returns the key of a path in the translations given to Merge
+func Key(path []string) string {
+	return strings.Join(path, "\x00")
+}
+
+type merger struct {
+	translations map[string]string
+	keepStyle    bool // whether the styles of the source apply, only between YAML files
+}
+
+// merge returns dst with the content of src merged into it, nil if there is nothing to put
+func (m merger) merge(dst, src *yaml.Node, path []string) *yaml.Node {
+	switch src.Kind {
+	case yaml.MappingNode:
+		if dst == nil || dst.Kind != yaml.MappingNode {
+			dst = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Style: m.style(src)}
+		}
+		for i := 0; i+1 < len(src.Content); i += 2 {
+			key := src.Content[i]
+			j := keyIndex(dst, key.Value)
+			var old *yaml.Node
+			if j >= 0 {
+				old = dst.Content[j+1]
+			}
+			n := m.merge(old, src.Content[i+1], append(path[:len(path):len(path)], key.Value))
+			switch {
+			case n == nil:
+			case j >= 0:
+				dst.Content[j+1] = n
+			default:
+				k := *key
+				k.Style = m.style(key)
+				dst.Content = append(dst.Content, &k, n)
+			}
+		}
+		return dst
+
+	case yaml.SequenceNode:
+		if dst == nil || dst.Kind != yaml.SequenceNode {
+			dst = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Style: m.style(src)}
+		}
+		for i, item := range src.Content {
+			var old *yaml.Node
+			if i < len(dst.Content) {
+				old = dst.Content[i]
+			}
+			n := m.merge(old, item, append(path[:len(path):len(path)], strconv.Itoa(i)))
+			if n == nil { // keeps the indexes of the next items
+				n = item
+			}
+			if i < len(dst.Content) {
+				dst.Content[i] = n
+			} else {
+				dst.Content = append(dst.Content, n)
+			}
+		}
+		return dst
+
+	case yaml.ScalarNode:
+		if isString(src) {
+			if translation, ok := m.translations[Key(path)]; ok {
+				// keeps the comments of the node it replaces
+				n := yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: translation, Style: m.style(src)}
+				if dst != nil {
+					n.HeadComment, n.LineComment, n.FootComment = dst.HeadComment, dst.LineComment, dst.FootComment
+				} else if m.keepStyle {
+					n.HeadComment, n.LineComment, n.FootComment = src.HeadComment, src.LineComment, src.FootComment
+				}
+				return &n
+			}
+		}
+		if dst != nil {
+			return dst
+		}
+		if isString(src) {
+			return nil
+		}
+	}
+	if dst != nil {
+		return dst
+	}
+	n := *src
+	n.Style = m.style(src)
+	return &n
+}
+
+// style returns the style of a node created from src
+func (m merger) style(src *yaml.Node) yaml.Style {
+	if m.keepStyle {
+		return src.Style
+	}
+	return 0
+}
+
+func keyIndex(mapping *yaml.Node, key string) int {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// child returns the value of key in a mapping or of the index key in a sequence
+func child(n *yaml.Node, key string) *yaml.Node {
+	switch n.Kind {
+	case yaml.MappingNode:
+		if i := keyIndex(n, key); i >= 0 {
+			return n.Content[i+1]
+		}
+	case yaml.SequenceNode:
+		if i, err := strconv.Atoi(key); err == nil && i >= 0 && i < len(n.Content) {
+			return n.Content[i]
+		}
+	}
+	return nil
+}
+
+func isString(n *yaml.Node) bool {
+	return n.Kind == yaml.ScalarNode && n.ShortTag() == "!!str"
+}
+
+// Marshal returns the bundle in its format
+func (b *Bundle) Marshal() ([]byte, error) {
+	buf := bytes.Buffer{}
+	if b.json {
+		if err := writeJSON(&buf, b.top(), b.indent, ""); err != nil {
+			return nil, err
+		}
+		buf.WriteByte('\n')
+		return buf.Bytes(), nil
+	}
+
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(len(strings.ReplaceAll(b.indent, "\t", "  ")))
+	if err := encoder.Encode(b.root); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), encoder.Close()
+}
+
+// Save writes the bundle to path
+func (b *Bundle) Save(path string) error {
+	data, err := b.Marshal()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// writeJSON writes n as indented JSON, keeping the order of the keys
+func writeJSON(buf *bytes.Buffer, n *yaml.Node, indent, prefix string) error {
+	switch n.Kind {
+	case yaml.MappingNode, yaml.SequenceNode:
+		open, close, step := "{", "}", 2
+		if n.Kind == yaml.SequenceNode {
+			open, close, step = "[", "]", 1
+		}
+		if len(n.Content) == 0 {
+			buf.WriteString(open + close)
+			return nil
+		}
+		buf.WriteString(open + "\n")
+		for i := 0; i < len(n.Content); i += step {
+			buf.WriteString(prefix + indent)
+			if step == 2 {
+				buf.Write(jsonString(n.Content[i].Value))
+				buf.WriteString(": ")
+			}
+			if err := writeJSON(buf, n.Content[i+step-1], indent, prefix+indent); err != nil {
+				return err
+			}
+			if i+step < len(n.Content) {
+				buf.WriteByte(',')
+			}
+			buf.WriteByte('\n')
+		}
+		buf.WriteString(prefix + close)
+
+	case yaml.ScalarNode:
+		switch n.ShortTag() {
+		case "!!str":
+			buf.Write(jsonString(n.Value))
+		case "!!null":
+			buf.WriteString("null")
+		case "!!bool":
+			buf.WriteString(strings.ToLower(n.Value))
+		default:
+			buf.WriteString(n.Value)
+		}
+
+	case yaml.AliasNode:
+		return writeJSON(buf, n.Alias, indent, prefix)
+
+	default:
+		return errors.New("unsupported value at line " + strconv.Itoa(n.Line))
+	}
+	return nil
+}
+
+// jsonString returns s as a JSON string, without escaping html characters
+func jsonString(s string) []byte {
+	buf := bytes.Buffer{}
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.Encode(s)
+	return bytes.TrimRight(buf.Bytes(), "\n")
+}
@@ -0,0 +1,125 @@
+package bundle
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/fedeztk/got/internal/translatortest"
+)
+
+func TestTranslate(t *testing.T) {
+	b := &translatortest.Upper{}
+	texts := []string{
+		"Welcome {{name}}, see $t(other.key) or @:linked.key",
+		"You have {count, plural, =0 {no messages} one {# message} other {# messages from {sender}}}",
+		"{g, select, male {He} female {She} other {They}} liked {item}",
+	}
+	want := []string{
+		"WELCOME {{name}}, SEE $t(other.key) OR @:linked.key",
+		"YOU HAVE {count, plural, =0 {NO MESSAGES} one {# MESSAGE} other {# MESSAGES FROM {sender}}}",
+		"{g, select, male {HE} female {SHE} other {THEY}} LIKED {item}",
+	}
+	for i, r := range Translate(context.Background(), b, texts, "en", "it", "google") {
+		if r.Err != nil || r.Text != want[i] {
+			t.Errorf("got %q (%v), want %q", r.Text, r.Err, want[i])
+		}
+	}
+	for _, text := range b.Texts() {
+		if strings.ContainsAny(text, "{}#$@") || strings.Contains(text, "plural") {
+			t.Errorf("syntax reached the backend: %q", text)
+		}
+	}
+}
+
+func parse(t *testing.T, data string, json bool) *Bundle {
+	t.Helper()
+	b, err := Parse([]byte(data), json)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func marshal(t *testing.T, b *Bundle) string {
+	t.Helper()
+	data, err := b.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestMergeJSON(t *testing.T) {
+	src := parse(t, `{
+    "title": "Title",
+    "count": 3,
+    "menu": {"open": "Open", "close": "Close <b>now</b>"},
+    "list": ["a", "b", null]
+}`, true)
+	dst := parse(t, `{
+  "menu": {"close": "Chiudi"},
+  "extra": "Extra"
+}`, true)
+
+	translations := map[string]string{}
+	for _, leaf := range src.Strings() {
+		if translated, ok := dst.Get(leaf.Path); !ok || translated == "" {
+			translations[Key(leaf.Path)] = strings.ToUpper(leaf.Value)
+		}
+	}
+	delete(translations, Key([]string{"list", "1"}))
+	dst.Merge(src, translations)
+
+	want := `{
+  "menu": {
+    "close": "Chiudi",
+    "open": "OPEN"
+  },
+  "extra": "Extra",
+  "title": "TITLE",
+  "count": 3,
+  "list": [
+    "A",
+    "b",
+    null
+  ]
+}
+`
+	if got := marshal(t, dst); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+
+	// a new bundle takes the indentation of the source
+	dst = New(true)
+	dst.Merge(src, nil)
+	if got := marshal(t, dst); !strings.HasPrefix(got, "{\n    \"count\": 3,") {
+		t.Errorf("got\n%s", got)
+	}
+}
+
+func TestMergeYAML(t *testing.T) {
+	src := parse(t, `# Rails locale
+en:
+  greeting: "Hello %{name}" # inline
+  days: [Sun, Mon]
+  enabled: true
+`, false)
+	src.RenameRoot("en", "it")
+	dst := New(false)
+	translations := map[string]string{}
+	for _, leaf := range src.Strings() {
+		translations[Key(leaf.Path)] = strings.ToUpper(leaf.Value)
+	}
+	dst.Merge(src, translations)
+
+	want := `# Rails locale
+it:
+  greeting: "HELLO %{NAME}" # inline
+  days: [SUN, MON]
+  enabled: true
+`
+	if got := marshal(t, dst); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
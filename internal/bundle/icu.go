@@ -0,0 +1,115 @@
+package bundle
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/fedeztk/got/pkg/translator"
+)
+
+// icuHeader matches the start of an ICU plural or select argument, like {count, plural,
+var icuHeader = regexp.MustCompile(`\{\s*\w+\s*,\s*(plural|select|selectordinal)\s*,(?:\s*offset:\d+)?`)
+
+// maskICU replaces the syntax of an ICU message with placeholders added to p:
+// arguments, selectors, braces and the # of plural branches. Consecutive
+// pieces of syntax share a placeholder
+func maskICU(message string, p *translator.Placeholders) string {
+	m := icuMasker{p: p}
+	for i := 0; i < len(message); {
+		i = m.message(message, i, false)
+		if i < len(message) { // unbalanced closing brace
+			m.syntax.WriteByte('}')
+			i++
+		}
+	}
+	m.flush()
+	return m.out.String()
+}
+
+type icuMasker struct {
+	p      *translator.Placeholders
+	out    strings.Builder
+	syntax strings.Builder // syntax not yet replaced by a placeholder
+}
+
+// flush replaces the pending syntax with a placeholder
+func (m *icuMasker) flush() {
+	if m.syntax.Len() > 0 {
+		m.out.WriteString(m.p.Add(m.syntax.String()))
+		m.syntax.Reset()
+	}
+}
+
+// text adds text to translate, its placeholders are masked too
+func (m *icuMasker) text(text string) {
+	if text == "" {
+		return
+	}
+	m.flush()
+	m.out.WriteString(m.p.Protect(text, Placeholders))
+}
+
+// message masks the message starting at i up to the closing brace of the
+// branch it is in, or the end, and returns the position of that brace
+func (m *icuMasker) message(s string, i int, plural bool) int {
+	start := i
+	for i < len(s) {
+		switch {
+		case s[i] == '{':
+			m.text(s[start:i])
+			i = m.argument(s, i)
+			start = i
+			continue
+		case s[i] == '}':
+			m.text(s[start:i])
+			return i
+		case s[i] == '#' && plural:
+			m.text(s[start:i])
+			m.syntax.WriteByte('#')
+			start = i + 1
+		}
+		i++
+	}
+	m.text(s[start:])
+	return i
+}
+
+// argument masks the argument starting with the brace at i and returns the
+// position after it
+func (m *icuMasker) argument(s string, i int) int {
+	header := icuHeader.FindStringSubmatchIndex(s[i:])
+	if header == nil || header[0] != 0 { // a simple argument like {name}
+		end := strings.IndexByte(s[i:], '}')
+		if end < 0 {
+			m.syntax.WriteString(s[i:])
+			return len(s)
+		}
+		m.syntax.WriteString(s[i : i+end+1])
+		return i + end + 1
+	}
+	plural := s[i+header[2]:i+header[3]] != "select"
+	m.syntax.WriteString(s[i : i+header[1]])
+	i += header[1]
+
+	// selectors followed by their message, up to the closing brace
+	for i < len(s) {
+		j := i
+		for j < len(s) && s[j] != '{' && s[j] != '}' {
+			j++
+		}
+		if j == len(s) {
+			m.syntax.WriteString(s[i:])
+			return j
+		}
+		m.syntax.WriteString(s[i : j+1])
+		if s[j] == '}' {
+			return j + 1
+		}
+		i = m.message(s, j+1, plural)
+		if i < len(s) {
+			m.syntax.WriteByte('}')
+			i++
+		}
+	}
+	return i
+}
@@ -2,32 +2,116 @@ package config
 
 import (
 	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 )
 
+const (
+	defaultTimeout      = 10 * time.Second
+	defaultCacheTTL     = 30 * 24 * time.Hour
+	defaultCacheMaxSize = 10 << 20 // 10MB
+	defaultAudioMaxSize = 50 << 20 // 50MB
+)
+
+// defaultCompare are the engines compared when the config doesn't list them
+var defaultCompare = []string{
+	"simplytranslate:google",
+	"simplytranslate:iciba",
+	"simplytranslate:reverso",
+	"lingvatranslate",
+}
+
 type Config struct {
 	sourceLang, targetLang, engine, backend string
+	apiKey                                  string
+	instances                               []string
+	instancesBackend                        string // backend the instances set from the command line are for
+	timeout                                 time.Duration
+	cacheTTL                                time.Duration
+	cacheMaxSize                            int64
+	audioCacheMaxSize                       int64
+	noCache                                 bool
+	audioDir                                string
 }
 
+// NewConfig reads the config file, writing the default one when there is none
 func NewConfig() *Config {
+	if readConfig() != nil {
+		writeDefaultConfig()
+	}
+	return newConfig()
+}
+
+// ReadConfig reads the config file like NewConfig but never writes it, so
+// that the one shot modes leave no file behind
+func ReadConfig() *Config {
+	readConfig()
+	return newConfig()
+}
+
+func readConfig() error {
 	home, _ := os.UserHomeDir()
 	viper.SetConfigName("config")
 	viper.SetConfigType("yml")
 	viper.AddConfigPath(home + "/.config/got")
+	return viper.ReadInConfig()
+}
 
-	err := viper.ReadInConfig()
-	if err != nil {
-		writeDefaultConfig()
-	}
+func newConfig() *Config {
+	home, _ := os.UserHomeDir()
 	return &Config{
 		sourceLang: viper.GetString("source"),
 		targetLang: viper.GetString("target"),
 		engine:     viper.GetString("engine"),
 		backend:    viper.GetString("backend"),
+		apiKey:     viper.GetString("apikey"),
+		timeout:    getDuration("timeout", defaultTimeout),
+
+		cacheTTL:          getDuration("cache.ttl", defaultCacheTTL),
+		cacheMaxSize:      getSize("cache.max_size", defaultCacheMaxSize),
+		audioCacheMaxSize: getSize("cache.audio_max_size", defaultAudioMaxSize),
+
+		audioDir: getPath("tts.dir", filepath.Join(home, "Music", "got")),
 	}
 }
 
+// getDuration returns the duration at key, or def if it is not set or not positive
+func getDuration(key string, def time.Duration) time.Duration {
+	if d := viper.GetDuration(key); d > 0 {
+		return d
+	}
+	return def
+}
+
+// getSize returns the size in bytes at key (e.g. 10mb), or def if it is not set
+func getSize(key string, def int64) int64 {
+	if s := int64(viper.GetSizeInBytes(key)); s > 0 {
+		return s
+	}
+	return def
+}
+
+// getPath returns the path at key with a leading ~ expanded, or def if it is not set
+func getPath(key, def string) string {
+	p := viper.GetString(key)
+	if p == "" {
+		return def
+	}
+	return expandHome(p)
+}
+
+// expandHome replaces a leading ~ in p with the home directory
+func expandHome(p string) string {
+	if p == "~" || strings.HasPrefix(p, "~/") {
+		home, _ := os.UserHomeDir()
+		p = filepath.Join(home, p[1:])
+	}
+	return p
+}
+
 type write struct {
 	key, value string
 }
@@ -61,6 +145,88 @@ func (c *Config) Backend() string {
 	return c.backend
 }
 
+func (c *Config) APIKey() string {
+	return c.apiKey
+}
+
+// Timeout returns the timeout of every request sent to the backend
+func (c *Config) Timeout() time.Duration {
+	return c.timeout
+}
+
+// CacheTTL returns how long translations are served from the cache
+func (c *Config) CacheTTL() time.Duration {
+	return c.cacheTTL
+}
+
+// CacheMaxSize returns the size in bytes of the translations cache
+func (c *Config) CacheMaxSize() int64 {
+	return c.cacheMaxSize
+}
+
+// AudioCacheMaxSize returns the size in bytes of the text to speech audio cache
+func (c *Config) AudioCacheMaxSize() int64 {
+	return c.audioCacheMaxSize
+}
+
+func (c *Config) UseCache() bool {
+	return !c.noCache
+}
+
+// AudioDir returns the directory text to speech audio is saved to
+func (c *Config) AudioDir() string {
+	return c.audioDir
+}
+
+// TTSEngines returns the local speech engine of each language, used when the
+// text to speech of the backend fails
+func (c *Config) TTSEngines() map[string]string {
+	return viper.GetStringMapString("tts.engines")
+}
+
+// Glossaries returns the glossary file of each language pair, keyed like en-it
+func (c *Config) Glossaries() map[string]string {
+	glossaries := viper.GetStringMapString("glossary")
+	for pair, path := range glossaries {
+		glossaries[pair] = expandHome(path)
+	}
+	return glossaries
+}
+
+// Phrasebook returns the file the starred translations are saved to, empty
+// for the default location
+func (c *Config) Phrasebook() string {
+	return getPath("phrasebook", "")
+}
+
+// DisableCache makes every translation hit the backend
+func (c *Config) DisableCache() {
+	c.noCache = true
+}
+
+// Instances returns the instance urls of the current backend, either set
+// from the command line or read from the instances section of the config
+func (c *Config) Instances() []string {
+	return c.BackendInstances(c.backend)
+}
+
+// BackendInstances returns the instance urls of backend, which is not
+// necessarily the current one
+func (c *Config) BackendInstances(backend string) []string {
+	if len(c.instances) > 0 && backend == c.instancesBackend {
+		return c.instances
+	}
+	return viper.GetStringSlice("instances." + backend)
+}
+
+// CompareEngines returns the backend:engine pairs shown in the compare tab
+func (c *Config) CompareEngines() []string {
+	if engines := viper.GetStringSlice("compare"); len(engines) > 0 {
+		return engines
+	}
+	return defaultCompare
+}
+
 func (c *Config) SetEngine(engine string) {
 	c.engine = engine
 }
@@ -69,6 +235,17 @@ func (c *Config) SetBackend(backend string) {
 	c.backend = backend
 }
 
+// SetInstances sets the instance urls of the current backend from a comma separated list
+func (c *Config) SetInstances(instances string) {
+	c.instancesBackend = c.backend
+	c.instances = nil
+	for _, i := range strings.Split(instances, ",") {
+		if i = strings.TrimSpace(i); i != "" {
+			c.instances = append(c.instances, i)
+		}
+	}
+}
+
 func (c *Config) RememberLastSettings(source, target string) {
 	writeConfig(
 		write{"source", source},
@@ -0,0 +1,162 @@
+// Package document splits Markdown and HTML documents in segments, so that
+// only their prose is translated while code, links and markup are kept as
+// they are
+package document
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/fedeztk/got/pkg/translator"
+)
+
+// Inline matches what must reach the translation unchanged inside prose: code
+// spans, the brackets and destinations of links and images, footnote
+// references, html code elements and tags, urls, entities, heading ids and
+// backslash escapes
+var Inline = regexp.MustCompile(strings.Join([]string{
+	"``.+?``",
+	"`[^`]+`",
+	`\[\^[^\]\s]+\]`,
+	`!?\[`,
+	`\](?:\([^()\s]*(?:\s+"[^"]*")?\)|\[[^\]]*\])?`,
+	`(?is:<code\b[^>]*>.*?</code>)`,
+	`<[a-zA-Z/!][^<>]*>`,
+	`(?:https?|ftp)://[^\s<>()\]]*[^\s<>()\].,;:!?'"]`,
+	`&(?:#\d+|#x[0-9a-fA-F]+|[a-zA-Z]+);`,
+	`\{#[\w-]+\}`,
+	"\\\\[\\\\`*_{}\\[\\]()#+\\-.!|]",
+}, "|"))
+
+// Segment is a piece of a document, only prose is translated
+type Segment struct {
+	Text   string
+	Prose  bool
+	breaks []string // line breaks of a prose paragraph, with the prefixes of the lines they start
+}
+
+// lines returns the lines of the paragraph of s, without their prefixes
+func (s Segment) lines() []string {
+	lines := []string{}
+	rest := s.Text
+	for _, br := range s.breaks {
+		i := strings.Index(rest, br)
+		if i < 0 {
+			break
+		}
+		lines = append(lines, rest[:i])
+		rest = rest[i+len(br):]
+	}
+	return append(lines, rest)
+}
+
+// rebreak returns translation, the translation of the lines of s joined,
+// wrapped on the lines of s
+func (s Segment) rebreak(translation string) string {
+	if len(s.breaks) == 0 {
+		return translation
+	}
+	trimmed := strings.TrimSpace(translation)
+	start := strings.Index(translation, trimmed)
+	builder := strings.Builder{}
+	builder.WriteString(translation[:start])
+	lines := translator.Rewrap(trimmed, len(s.breaks)+1)
+	for i, line := range lines {
+		builder.WriteString(line)
+		if i < len(lines)-1 {
+			builder.WriteString(s.breaks[i])
+		}
+	}
+	builder.WriteString(translation[start+len(trimmed):])
+	return builder.String()
+}
+
+// Join returns the document made of segments
+func Join(segments []Segment) string {
+	builder := strings.Builder{}
+	for _, s := range segments {
+		builder.WriteString(s.Text)
+	}
+	return builder.String()
+}
+
+// Translate translates the prose segments to target, their Inline parts
+// excluded. The returned errors follow the order of segments, nil for the
+// translated ones and the ones that are not prose, which are left as they are
+func Translate(ctx context.Context, b translator.Backend, segments []Segment, source, target, engine string) []error {
+	errs := make([]error, len(segments))
+	indexes := []int{}
+	texts := []string{}
+	for i, s := range segments {
+		if s.Prose && hasWords(s.Text) {
+			indexes = append(indexes, i)
+			texts = append(texts, strings.Join(s.lines(), " "))
+		}
+	}
+	for i, r := range translator.TranslateTexts(ctx, b, texts, source, target, engine, translator.MaxWorkers, Inline) {
+		if r.Err != nil {
+			errs[indexes[i]] = r.Err
+			continue
+		}
+		segments[indexes[i]].Text = segments[indexes[i]].rebreak(r.Text)
+	}
+	return errs
+}
+
+// Prose returns the number of segments Translate sends to the backend
+func Prose(segments []Segment) int {
+	n := 0
+	for _, s := range segments {
+		if s.Prose && hasWords(s.Text) {
+			n++
+		}
+	}
+	return n
+}
+
+// hasWords reports whether text has letters out of its Inline parts
+func hasWords(text string) bool {
+	return strings.IndexFunc(Inline.ReplaceAllString(text, ""), unicode.IsLetter) >= 0
+}
+
+// builder collects segments, merging the consecutive ones of the same kind
+type builder struct {
+	segments []Segment
+}
+
+func (b *builder) add(text string, prose bool) {
+	if text == "" {
+		return
+	}
+	if n := len(b.segments); n > 0 && b.segments[n-1].Prose == prose && b.segments[n-1].breaks == nil {
+		b.segments[n-1].Text += text
+		return
+	}
+	b.segments = append(b.segments, Segment{Text: text, Prose: prose})
+}
+
+func (b *builder) keep(text string) {
+	b.add(text, false)
+}
+
+func (b *builder) prose(text string) {
+	b.add(text, true)
+}
+
+// paragraph adds the prose of lines separated by breaks as a segment of its own
+func (b *builder) paragraph(lines, breaks []string) {
+	if len(breaks) == 0 {
+		b.prose(lines[0])
+		return
+	}
+	builder := strings.Builder{}
+	for i, line := range lines {
+		builder.WriteString(line)
+		if i < len(breaks) {
+			builder.WriteString(breaks[i])
+		}
+	}
+	b.segments = append(b.segments, Segment{Text: builder.String(), Prose: true, breaks: breaks})
+}
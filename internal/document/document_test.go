@@ -0,0 +1,132 @@
+package document
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/fedeztk/got/internal/translatortest"
+)
+
+func translate(t *testing.T, segments []Segment) (string, *translatortest.Upper) {
+	t.Helper()
+	b := &translatortest.Upper{}
+	for _, err := range Translate(context.Background(), b, segments, "en", "it", "google") {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	return Join(segments), b
+}
+
+const markdown = "---\ntitle: Guide\n---\n" + `# Getting started {#start}
+
+Install it with ` + "`go install`" + ` or read
+the [docs](https://example.org/docs "Docs") at https://example.org.
+
+` + "```sh\ngot -h\n```" + `
+
+    indented code
+
+- First item
+  continued
+- Second *item* with ![a logo](logo.png)
+    - Nested item
+
+> Quoted text
+> on two lines
+
+| Name | Description |
+|------|-------------|
+| ` + "`-s`" + ` | source language |
+
+Line with a break  
+after it &amp; a note[^1].
+
+<!-- a comment
+on two lines -->
+<div align="center">Centered text</div>
+
+[docs]: https://example.org/docs
+`
+
+func TestMarkdown(t *testing.T) {
+	segments := ParseMarkdown(markdown)
+	if Join(segments) != markdown {
+		t.Fatal("the segments don't give back the document")
+	}
+	got, b := translate(t, segments)
+
+	want := "---\ntitle: Guide\n---\n" + `# GETTING STARTED {#start}
+
+INSTALL IT WITH ` + "`go install`" + ` OR READ THE
+[DOCS](https://example.org/docs "Docs") AT https://example.org.
+
+` + "```sh\ngot -h\n```" + `
+
+    indented code
+
+- FIRST ITEM
+  CONTINUED
+- SECOND *ITEM* WITH ![A LOGO](logo.png)
+    - NESTED ITEM
+
+> QUOTED TEXT
+> ON TWO LINES
+
+| NAME | DESCRIPTION |
+|------|-------------|
+| ` + "`-s`" + ` | SOURCE LANGUAGE |
+
+LINE WITH A BREAK  
+AFTER IT &amp; A NOTE[^1].
+
+<!-- a comment
+on two lines -->
+<div align="center">CENTERED TEXT</div>
+
+[docs]: https://example.org/docs
+`
+	if got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+	for _, text := range b.Texts() {
+		if strings.Contains(text, "\n") {
+			t.Errorf("paragraph sent in pieces: %q", text)
+		}
+		if strings.ContainsAny(text, "`<>&()") || strings.Contains(text, "http") {
+			t.Errorf("markup reached the backend: %q", text)
+		}
+	}
+}
+
+const html = `<!DOCTYPE html>
+<html lang="en">
+<head><title>Guide</title><style>p { color: red; }</style></head>
+<body>
+  <p class="intro">Click <a href="/start" title="Start">here</a> to run <code>got -h</code>.</p>
+  <pre>kept
+as is</pre>
+  <script>var text = "not translated";</script>
+</body>
+</html>
+`
+
+func TestHTML(t *testing.T) {
+	segments := ParseHTML(html)
+	if Join(segments) != html {
+		t.Fatal("the segments don't give back the document")
+	}
+	got, b := translate(t, segments)
+
+	want := strings.NewReplacer(
+		"<title>Guide", "<title>GUIDE",
+		`>Click <a href="/start" title="Start">here</a> to run`, `>CLICK <a href="/start" title="Start">HERE</a> TO RUN`,
+	).Replace(html)
+	if got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+	if len(b.Texts()) != 2 {
+		t.Errorf("got %d requests, want 2 as blank text is not sent: %q", len(b.Texts()), b.Texts())
+	}
+}
@@ -0,0 +1,79 @@
+package document
+
+import (
+	"regexp"
+	"strings"
+)
+
+var tag = regexp.MustCompile(`^<(/?)([a-zA-Z][\w-]*)[^<>]*>`)
+
+// blockTags are the elements around which prose is split, the other tags are
+// part of the prose, protected by Inline
+var blockTags = map[string]bool{
+	"html": true, "head": true, "body": true, "title": true, "meta": true, "link": true,
+	"header": true, "footer": true, "main": true, "nav": true, "section": true, "article": true, "aside": true,
+	"div": true, "p": true, "br": true, "hr": true, "blockquote": true, "figure": true, "figcaption": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"ul": true, "ol": true, "li": true, "dl": true, "dt": true, "dd": true,
+	"table": true, "caption": true, "thead": true, "tbody": true, "tfoot": true, "tr": true, "th": true, "td": true,
+	"form": true, "fieldset": true, "legend": true, "label": true, "option": true, "button": true,
+	"details": true, "summary": true, "img": true, "video": true, "audio": true, "iframe": true,
+}
+
+// rawTags are the elements whose content is never translated
+var rawTags = map[string]bool{"script": true, "style": true, "pre": true, "textarea": true, "svg": true}
+
+// ParseHTML splits an html document in segments: the text between block
+// elements is prose, tags with their attributes, comments and the content of
+// elements like script, style or pre are kept as they are
+func ParseHTML(text string) []Segment {
+	b := builder{}
+	for text != "" {
+		i := strings.IndexByte(text, '<')
+		if i < 0 {
+			b.prose(text)
+			break
+		}
+		b.prose(text[:i])
+		text = text[i:]
+
+		end := 0
+		switch {
+		case strings.HasPrefix(text, "<!--"):
+			end = index(text, "-->")
+		case strings.HasPrefix(text, "<!"), strings.HasPrefix(text, "<?"): // doctype or processing instruction
+			end = index(text, ">")
+		default:
+			match := tag.FindStringSubmatch(text)
+			if match == nil { // a lone <
+				b.prose("<")
+				text = text[1:]
+				continue
+			}
+			name := strings.ToLower(match[2])
+			end = len(match[0])
+			if rawTags[name] && match[1] == "" {
+				end = len(match[0]) + index(strings.ToLower(text[len(match[0]):]), "</"+name)
+				end += index(text[end:], ">")
+			} else if !blockTags[name] {
+				if name == "code" && match[1] == "" { // masked whole by Inline
+					end += index(strings.ToLower(text[end:]), "</code>")
+				}
+				b.prose(text[:end])
+				text = text[end:]
+				continue
+			}
+		}
+		b.keep(text[:end])
+		text = text[end:]
+	}
+	return b.segments
+}
+
+// index returns the position after the first sep in s, the length of s without it
+func index(s, sep string) int {
+	if i := strings.Index(s, sep); i >= 0 {
+		return i + len(sep)
+	}
+	return len(s)
+}
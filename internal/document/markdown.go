@@ -0,0 +1,209 @@
+package document
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	fence        = regexp.MustCompile("^ {0,3}(`{3,}|~{3,})")
+	rawHTML      = regexp.MustCompile(`(?i)^ {0,3}<(pre|script|style|textarea|!--)`)
+	definition   = regexp.MustCompile(`^ {0,3}\[[^\]]+\]:\s`)
+	thematic     = regexp.MustCompile(`^ {0,3}(?:(?:-\s*){3,}|(?:\*\s*){3,}|(?:_\s*){3,}|(?:=\s*)+)$`)
+	tableDivider = regexp.MustCompile(`^\s*\|?\s*:?-+:?\s*(?:\|\s*:?-+:?\s*)*\|?\s*$`)
+	blockPrefix  = regexp.MustCompile(`^(\s*(?:>\s?)*)(\s*(?:(?:[-*+]|\d{1,9}[.)])\s+(?:\[[ xX]\]\s+)?|#{1,6}\s+))?`)
+	closingHash  = regexp.MustCompile(`\s+#+\s*$`)
+)
+
+// ParseMarkdown splits a Markdown document in segments: the prose of
+// paragraphs, headings, list items, quotes and table cells, every paragraph
+// in a single segment translated as a whole and wrapped back on as many
+// lines, and everything else kept as it is, like front matter, code blocks,
+// raw html blocks and link definitions
+func ParseMarkdown(text string) []Segment {
+	p := markdownParser{lines: strings.SplitAfter(text, "\n")}
+	p.parse()
+	return p.segments
+}
+
+type markdownParser struct {
+	builder
+	lines  []string
+	para   []string // lines of the current paragraph, without their prefixes
+	breaks []string // what separates the lines of the paragraph: line endings and prefixes
+	quotes int      // depth of the quotes of the current paragraph
+	eol    string   // line ending of the last line of the paragraph
+	inList bool     // whether the last block is a list, whose items may be indented
+}
+
+// flush adds the current paragraph
+func (p *markdownParser) flush() {
+	if len(p.para) > 0 {
+		p.paragraph(p.para, p.breaks)
+		p.keep(p.eol)
+		p.para, p.breaks = nil, nil
+	}
+}
+
+// keepUntil keeps the lines from i up to the first one after i matching end,
+// and returns the index of the line after it
+func (p *markdownParser) keepUntil(i int, end func(line string) bool) int {
+	p.keep(p.lines[i])
+	for i++; i < len(p.lines); i++ {
+		p.keep(p.lines[i])
+		if end(p.lines[i]) {
+			return i + 1
+		}
+	}
+	return i
+}
+
+func (p *markdownParser) parse() {
+	i := 0
+	if len(p.lines) > 0 && strings.TrimSpace(p.lines[0]) == "---" { // front matter
+		i = p.keepUntil(0, func(line string) bool {
+			line = strings.TrimSpace(line)
+			return line == "---" || line == "..."
+		})
+	}
+
+	blank := true // whether the previous line is blank
+	for i < len(p.lines) {
+		line := p.lines[i]
+		content := strings.TrimRight(line, "\r\n")
+		eol := line[len(content):]
+		trimmed := strings.TrimSpace(content)
+		indented := strings.HasPrefix(content, "    ") || strings.HasPrefix(content, "\t")
+
+		switch {
+		case trimmed == "":
+			p.flush()
+			p.keep(line)
+			blank = true
+			i++
+			continue
+
+		case fence.MatchString(content):
+			p.flush()
+			marker := strings.TrimSpace(fence.FindStringSubmatch(content)[1])
+			i = p.keepUntil(i, func(line string) bool {
+				line = strings.TrimSpace(line)
+				return strings.HasPrefix(line, marker) && strings.Trim(line, marker[:1]) == ""
+			})
+
+		case indented && blank && len(p.para) == 0 && !p.inList: // indented code
+			p.keep(line)
+			i++
+
+		case rawHTML.MatchString(content):
+			p.flush()
+			tag := strings.ToLower(rawHTML.FindStringSubmatch(content)[1])
+			end := "</" + tag + ">"
+			if tag == "!--" {
+				end = "-->"
+			}
+			if strings.Contains(strings.ToLower(content), end) {
+				p.keep(line)
+				i++
+			} else {
+				i = p.keepUntil(i, func(line string) bool { return strings.Contains(strings.ToLower(line), end) })
+			}
+
+		case definition.MatchString(content), thematic.MatchString(content), isDivider(content):
+			p.flush()
+			p.keep(line)
+			p.inList = false
+			i++
+
+		case strings.Contains(content, "|") && (p.table(i) || (i+1 < len(p.lines) && isDivider(p.lines[i+1]))):
+			p.flush()
+			p.row(content)
+			p.keep(eol)
+			i++
+
+		default:
+			p.text(content, eol, indented)
+			i++
+		}
+		blank = false
+	}
+	p.flush()
+}
+
+// table reports whether line i continues a table
+func (p *markdownParser) table(i int) bool {
+	for j := i - 1; j >= 0; j-- {
+		previous := strings.TrimSpace(p.lines[j])
+		if !strings.Contains(previous, "|") {
+			return false
+		}
+		if isDivider(previous) {
+			return true
+		}
+	}
+	return false
+}
+
+// row adds the cells of a table row, the pipes between them and their
+// surrounding spaces are kept
+func (p *markdownParser) row(content string) {
+	start := 0
+	for i := 0; i < len(content); i++ {
+		if content[i] != '|' || (i > 0 && content[i-1] == '\\') {
+			continue
+		}
+		cell := strings.TrimRight(content[start:i], " \t")
+		end := i + 1
+		for end < len(content) && (content[end] == ' ' || content[end] == '\t') {
+			end++
+		}
+		p.prose(cell)
+		p.keep(content[start+len(cell) : end])
+		start, i = end, end-1
+	}
+	p.prose(content[start:])
+}
+
+// isDivider reports whether line is the one separating the header of a table from its rows
+func isDivider(line string) bool {
+	return strings.Contains(line, "|") && tableDivider.MatchString(line)
+}
+
+// text adds a line of prose, continuing the current paragraph when it has
+// no block marker
+func (p *markdownParser) text(content, eol string, indented bool) {
+	match := blockPrefix.FindStringSubmatch(content)
+	quotes, marker := strings.Count(match[1], ">"), match[2]
+	rest := content[len(match[0]):]
+
+	if len(p.para) > 0 && marker == "" && quotes == p.quotes {
+		trimmed := strings.TrimLeft(rest, " \t")
+		p.breaks = append(p.breaks, p.eol+content[:len(content)-len(trimmed)])
+		p.para = append(p.para, trimmed)
+	} else {
+		p.flush()
+		p.keep(match[0])
+		p.para, p.quotes = []string{rest}, quotes
+	}
+	p.eol = eol
+	if marker != "" {
+		p.inList = !strings.HasPrefix(strings.TrimSpace(marker), "#")
+	} else if !indented && quotes == 0 {
+		p.inList = false
+	}
+
+	switch {
+	case strings.HasPrefix(strings.TrimSpace(marker), "#"): // headings are a single line
+		last := p.para[len(p.para)-1]
+		if loc := closingHash.FindStringIndex(last); loc != nil {
+			p.para[len(p.para)-1] = last[:loc[0]]
+			p.eol = last[loc[0]:] + eol
+		}
+		p.flush()
+	case strings.HasSuffix(content, "  ") || strings.HasSuffix(content, "\\"): // hard line break
+		last := strings.TrimRight(p.para[len(p.para)-1], " \\")
+		p.eol = p.para[len(p.para)-1][len(last):] + eol
+		p.para[len(p.para)-1] = last
+		p.flush()
+	}
+}
@@ -0,0 +1,81 @@
+package format
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/fedeztk/got/pkg/translator"
+)
+
+// comparisonJSON is the translation of one of the compared engines in the json format
+type comparisonJSON struct {
+	Backend     string   `json:"backend"`
+	Engine      string   `json:"engine"`
+	Translation string   `json:"translation,omitempty"`
+	Differing   []string `json:"differingWords,omitempty"`
+	Error       string   `json:"error,omitempty"`
+}
+
+// RenderComparisons returns the translations of the compared engines in the
+// given format, highlighting the words they disagree on where the format allows it
+func RenderComparisons(comparisons []translator.Comparison, format string) (string, error) {
+	words := translator.CompareWords(comparisons)
+
+	switch format {
+	case "pretty":
+		return translator.PrettyPrintComparisons(comparisons), nil
+	case "plain":
+		lines := make([]string, 0, len(comparisons))
+		for _, c := range comparisons {
+			lines = append(lines, c.Engine.String()+": "+comparisonText(c))
+		}
+		return strings.Join(lines, "\n"), nil
+	case "json":
+		out := make([]comparisonJSON, 0, len(comparisons))
+		for i, c := range comparisons {
+			j := comparisonJSON{Backend: c.Engine.Backend, Engine: c.Engine.Engine}
+			if c.Err != nil {
+				j.Error = c.Err.Error()
+			} else {
+				j.Translation = c.Response.ShortTranslatedText()
+			}
+			for _, w := range words[i] {
+				if w.Differs {
+					j.Differing = append(j.Differing, w.Text)
+				}
+			}
+			out = append(out, j)
+		}
+		data, err := json.MarshalIndent(out, "", "  ")
+		return string(data), err
+	case "markdown":
+		builder := strings.Builder{}
+		builder.WriteString("| Engine | Translation |\n| --- | --- |\n")
+		for i, c := range comparisons {
+			text := comparisonText(c)
+			if c.Err == nil {
+				rendered := make([]string, 0, len(words[i]))
+				for _, w := range words[i] {
+					if w.Differs {
+						rendered = append(rendered, "**"+w.Text+"**")
+					} else {
+						rendered = append(rendered, w.Text)
+					}
+				}
+				text = strings.Join(rendered, " ")
+			}
+			builder.WriteString("| " + c.Engine.String() + " | " + strings.ReplaceAll(text, "|", "\\|") + " |\n")
+		}
+		return builder.String(), nil
+	default:
+		return "", Check(format)
+	}
+}
+
+// comparisonText returns the translation of c, or its error
+func comparisonText(c translator.Comparison) string {
+	if c.Err != nil {
+		return "error: " + c.Err.Error()
+	}
+	return c.Response.ShortTranslatedText()
+}
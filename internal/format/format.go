@@ -0,0 +1,103 @@
+// Package format renders translations in the output formats of one shot mode
+package format
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/fedeztk/got/pkg/translator/utils"
+)
+
+// Formats lists the supported output formats, the first one is the default
+var Formats = []string{"pretty", "plain", "json", "markdown"}
+
+// Check returns an error if format is not supported
+func Check(format string) error {
+	for _, f := range Formats {
+		if f == format {
+			return nil
+		}
+	}
+	return errors.New("output format not supported, please use one of the following: " + strings.Join(Formats, ", "))
+}
+
+// Render returns r in the given format
+func Render(r utils.BackendResponse, format string) (string, error) {
+	switch format {
+	case "pretty":
+		return r.PrettyPrint(), nil
+	case "plain":
+		return r.ShortTranslatedText(), nil
+	case "json":
+		out, err := json.MarshalIndent(r.Result(), "", "  ")
+		return string(out), err
+	case "markdown":
+		return Markdown(r.Result()), nil
+	default:
+		return "", Check(format)
+	}
+}
+
+// Markdown renders r as a markdown document
+func Markdown(r utils.Result) string {
+	builder := strings.Builder{}
+	if strings.Contains(r.Translation, "\n") { // long texts don't fit in the title
+		builder.WriteString("# Translation\n\n" + strings.TrimSpace(r.Translation) + "\n")
+	} else {
+		builder.WriteString("# " + r.Translation + "\n")
+	}
+	if r.Detected != "" {
+		builder.WriteString("\n*Detected language: " + r.Detected + "*\n")
+	}
+	if r.Pronunciation != "" {
+		builder.WriteString("\n*Pronunciation: " + r.Pronunciation + "*\n")
+	}
+	if len(r.Alternatives) > 0 {
+		builder.WriteString("\n## Alternatives\n\n")
+		for _, alternative := range r.Alternatives {
+			builder.WriteString("- " + alternative + "\n")
+		}
+	}
+	if len(r.Definitions) > 0 {
+		builder.WriteString("\n## Definitions\n")
+		for _, group := range r.Definitions {
+			if group.PartOfSpeech != "" {
+				builder.WriteString("\n### " + group.PartOfSpeech + "\n")
+			}
+			builder.WriteString("\n")
+			for _, d := range group.Definitions {
+				builder.WriteString("- " + d.Definition + "\n")
+				if d.Example != "" {
+					builder.WriteString("  - *Example:* " + d.Example + "\n")
+				}
+				if len(d.Synonyms) > 0 {
+					builder.WriteString("  - *Synonyms:* " + strings.Join(d.Synonyms, ", ") + "\n")
+				}
+				if d.Field != "" {
+					builder.WriteString("  - *Field:* " + d.Field + "\n")
+				}
+			}
+		}
+	}
+	if len(r.Examples) > 0 {
+		builder.WriteString("\n## Examples\n\n")
+		for _, example := range r.Examples {
+			builder.WriteString("- " + example + "\n")
+		}
+	}
+	if len(r.ExtraTranslations) > 0 {
+		builder.WriteString("\n## Extra translations\n")
+		for _, group := range r.ExtraTranslations {
+			if group.PartOfSpeech != "" {
+				builder.WriteString("\n### " + group.PartOfSpeech + "\n")
+			}
+			builder.WriteString("\n")
+			for _, t := range group.Translations {
+				builder.WriteString(fmt.Sprintf("- **%s**: %s\n", t.Word, strings.Join(t.Meanings, ", ")))
+			}
+		}
+	}
+	return builder.String()
+}
@@ -0,0 +1,104 @@
+package format
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/fedeztk/got/pkg/translator"
+	"github.com/fedeztk/got/pkg/translator/utils"
+)
+
+var result = utils.Result{
+	Translation:   "lid",
+	Pronunciation: "koˈpɛrkjo",
+	Definitions: []utils.DefinitionGroup{{
+		PartOfSpeech: "noun",
+		Definitions:  []utils.Definition{{Definition: "a cover", Synonyms: []string{"cap", "top"}}},
+	}},
+	ExtraTranslations: []utils.TranslationGroup{{
+		PartOfSpeech: "noun",
+		Translations: []utils.ExtraTranslation{{Word: "lid", Meanings: []string{"coperchio"}}},
+	}},
+}
+
+func TestRender(t *testing.T) {
+	for _, f := range Formats {
+		if _, err := Render(result, f); err != nil {
+			t.Errorf("%s: %v", f, err)
+		}
+	}
+	if _, err := Render(result, "xml"); err == nil {
+		t.Error("expected an error for an unsupported format")
+	}
+	if out, _ := Render(result, "plain"); out != "lid" {
+		t.Errorf("plain format should only print the translation, got %q", out)
+	}
+	if out, _ := Render(result, "json"); !strings.Contains(out, `"partOfSpeech": "noun"`) {
+		t.Errorf("json format is missing the definitions: %s", out)
+	}
+}
+
+func TestMarkdown(t *testing.T) {
+	out := Markdown(result)
+	for _, expected := range []string{"# lid\n", "### noun", "- a cover", "*Synonyms:* cap, top", "- **lid**: coperchio"} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("markdown is missing %q:\n%s", expected, out)
+		}
+	}
+}
+
+func TestRenderTargets(t *testing.T) {
+	results := []translator.TargetResult{
+		{Target: "en", Response: result},
+		{Target: "xx", Err: errors.New("unsupported language")},
+	}
+
+	plain, err := RenderTargets(results, "plain")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plain != "en: lid\nxx: error: unsupported language" {
+		t.Errorf("unexpected plain output %q", plain)
+	}
+
+	md, err := RenderTargets(results, "markdown")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"# en\n\n## lid\n", "\n### Definitions\n", "# xx\n"} {
+		if !strings.Contains(md, want) {
+			t.Errorf("markdown output misses %q:\n%s", want, md)
+		}
+	}
+
+	for _, f := range Formats {
+		if _, err := RenderTargets(results, f); err != nil {
+			t.Errorf("%s: %v", f, err)
+		}
+	}
+}
+
+func TestRenderComparisons(t *testing.T) {
+	comparisons := []translator.Comparison{
+		{Engine: translator.Engine{Backend: "simplytranslate", Engine: "google"}, Response: utils.Result{Translation: "the lid"}},
+		{Engine: translator.Engine{Backend: "simplytranslate", Engine: "reverso"}, Response: utils.Result{Translation: "the cover"}},
+		{Engine: translator.Engine{Backend: "lingvatranslate", Engine: "google"}, Err: errors.New("instance down")},
+	}
+
+	md, err := RenderComparisons(comparisons, "markdown")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"| simplytranslate:google | the **lid** |", "| simplytranslate:reverso | the **cover** |", "| lingvatranslate:google | error: instance down |"} {
+		if !strings.Contains(md, want) {
+			t.Errorf("markdown output misses %q:\n%s", want, md)
+		}
+	}
+
+	for _, f := range Formats {
+		if _, err := RenderComparisons(comparisons, f); err != nil {
+			t.Errorf("%s: %v", f, err)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package format
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/fedeztk/got/pkg/translator"
+	"github.com/fedeztk/got/pkg/translator/utils"
+)
+
+// targetJSON is a translation to one of several target languages in the json format
+type targetJSON struct {
+	Target string        `json:"target"`
+	Result *utils.Result `json:"result,omitempty"`
+	Error  string        `json:"error,omitempty"`
+}
+
+// RenderTargets returns the translations to several target languages in the
+// given format, one section per target
+func RenderTargets(results []translator.TargetResult, format string) (string, error) {
+	switch format {
+	case "pretty":
+		return translator.PrettyPrintTargets(results), nil
+	case "plain":
+		lines := make([]string, 0, len(results))
+		for _, r := range results {
+			lines = append(lines, r.Target+": "+targetText(r))
+		}
+		return strings.Join(lines, "\n"), nil
+	case "json":
+		out := make([]targetJSON, 0, len(results))
+		for _, r := range results {
+			t := targetJSON{Target: r.Target}
+			if r.Err != nil {
+				t.Error = r.Err.Error()
+			} else {
+				result := r.Response.Result()
+				t.Result = &result
+			}
+			out = append(out, t)
+		}
+		data, err := json.MarshalIndent(out, "", "  ")
+		return string(data), err
+	case "markdown":
+		sections := make([]string, 0, len(results))
+		for _, r := range results {
+			if r.Err != nil {
+				sections = append(sections, "# "+r.Target+"\n\n"+targetText(r)+"\n")
+				continue
+			}
+			sections = append(sections, "# "+r.Target+"\n\n"+demote(Markdown(r.Response.Result())))
+		}
+		return strings.Join(sections, "\n"), nil
+	default:
+		return "", Check(format)
+	}
+}
+
+// targetText returns the translation of r, or its error
+func targetText(r translator.TargetResult) string {
+	if r.Err != nil {
+		return "error: " + r.Err.Error()
+	}
+	return r.Response.ShortTranslatedText()
+}
+
+// demote moves every heading of the markdown document md one level down
+func demote(md string) string {
+	lines := strings.Split(md, "\n")
+	for i, l := range lines {
+		if strings.HasPrefix(l, "#") {
+			lines[i] = "#" + l
+		}
+	}
+	return strings.Join(lines, "\n")
+}
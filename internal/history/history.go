@@ -0,0 +1,133 @@
+// Package history keeps track of the translations done in the tui, stored as
+// json lines under the user data directory
+package history
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fedeztk/got/pkg/translator/utils"
+)
+
+// maxEntries is the number of most recent entries loaded from the history,
+// the older ones are trimmed from the file
+const maxEntries = 1000
+
+// Entry is a translation recorded in the history
+type Entry struct {
+	Query    string        `json:"query"`
+	Source   string        `json:"source"`
+	Target   string        `json:"target"`
+	Backend  string        `json:"backend"`
+	Engine   string        `json:"engine"`
+	Result   string        `json:"result"`             // short translated text
+	Response *utils.Result `json:"response,omitempty"` // full result
+	Time     time.Time     `json:"time"`
+}
+
+type Store struct {
+	path  string
+	added int        // entries added since the file was last trimmed
+	mu    sync.Mutex // translations to several targets are added concurrently
+}
+
+// NewStore returns the history stored under $XDG_DATA_HOME/got, defaulting
+// to ~/.local/share/got
+func NewStore() *Store {
+	dataDir := os.Getenv("XDG_DATA_HOME")
+	if dataDir == "" {
+		home, _ := os.UserHomeDir()
+		dataDir = filepath.Join(home, ".local", "share")
+	}
+	return &Store{path: filepath.Join(dataDir, "got", "history.jsonl")}
+}
+
+// Add appends e to the history, trimming its file every maxEntries entries
+func (s *Store) Add(e Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(s.path), os.ModePerm); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	line, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	if _, err = f.Write(append(line, '\n')); err != nil {
+		return err
+	}
+
+	if s.added++; s.added >= maxEntries {
+		_, err = s.entries()
+	}
+	return err
+}
+
+// Entries returns the most recent entries, newest first. Malformed lines are
+// skipped, and the older entries are trimmed from the file
+func (s *Store) Entries() ([]Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.entries()
+}
+
+func (s *Store) entries() ([]Entry, error) {
+	f, err := os.Open(s.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	entries, lines := []Entry{}, [][]byte{}
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024) // full results can be long
+	for scanner.Scan() {
+		var e Entry
+		if json.Unmarshal(scanner.Bytes(), &e) != nil {
+			continue
+		}
+		entries = append(entries, e)
+		lines = append(lines, append(append([]byte{}, scanner.Bytes()...), '\n'))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(entries) > maxEntries {
+		entries = entries[len(entries)-maxEntries:]
+		f.Close() // before replacing it
+		if err := s.rewrite(lines[len(lines)-maxEntries:]); err != nil {
+			return nil, err
+		}
+	}
+	s.added = 0
+	for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+		entries[i], entries[j] = entries[j], entries[i]
+	}
+	return entries, nil
+}
+
+// rewrite replaces the file with lines, through a temporary file so that the
+// history is never lost halfway
+func (s *Store) rewrite(lines [][]byte) error {
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, bytes.Join(lines, nil), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
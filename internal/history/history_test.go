@@ -0,0 +1,83 @@
+package history
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fedeztk/got/pkg/translator/utils"
+)
+
+func newTestStore(t *testing.T) *Store {
+	return &Store{path: filepath.Join(t.TempDir(), "got", "history.jsonl")}
+}
+
+func lines(t *testing.T, s *Store) int {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return bytes.Count(data, []byte("\n"))
+}
+
+func TestAddAndEntries(t *testing.T) {
+	s := newTestStore(t)
+	if entries, err := s.Entries(); err != nil || len(entries) != 0 {
+		t.Fatalf("expected an empty history, got %v, %v", entries, err)
+	}
+
+	now := time.Now().Round(0)
+	for _, query := range []string{"ciao", "mondo"} {
+		e := Entry{Query: query, Source: "it", Target: "en", Result: "hello", Response: &utils.Result{Translation: "hello"}, Time: now}
+		if err := s.Add(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	f, _ := os.OpenFile(s.path, os.O_APPEND|os.O_WRONLY, 0o644)
+	f.WriteString("not json\n")
+	f.Close()
+
+	entries, err := s.Entries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 || entries[0].Query != "mondo" || entries[1].Query != "ciao" {
+		t.Fatalf("expected the entries newest first, got %+v", entries)
+	}
+	if entries[0].Response == nil || entries[0].Response.Translation != "hello" || !entries[0].Time.Equal(now) {
+		t.Errorf("the entry was not reloaded as saved: %+v", entries[0])
+	}
+}
+
+func TestTrim(t *testing.T) {
+	s := newTestStore(t)
+	for i := 0; i < maxEntries+maxEntries/2; i++ {
+		if err := s.Add(Entry{Query: fmt.Sprint(i)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := lines(t, s); n != maxEntries+maxEntries/2 {
+		t.Fatalf("expected %d entries before loading them, got %d", maxEntries+maxEntries/2, n)
+	}
+
+	entries, err := s.Entries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != maxEntries || entries[0].Query != fmt.Sprint(maxEntries+maxEntries/2-1) {
+		t.Errorf("expected the %d newest entries, got %d starting from %s", maxEntries, len(entries), entries[0].Query)
+	}
+	if n := lines(t, s); n != maxEntries {
+		t.Errorf("expected the file trimmed to %d entries when loading it, got %d", maxEntries, n)
+	}
+
+	for i := 0; i < maxEntries; i++ {
+		s.Add(Entry{Query: "again"})
+	}
+	if n := lines(t, s); n != maxEntries {
+		t.Errorf("expected the file trimmed to %d entries while adding, got %d", maxEntries, n)
+	}
+}
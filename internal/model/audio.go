@@ -0,0 +1,55 @@
+package model
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+)
+
+// maxAudioNameLength is the maximum number of runes of the text used in audio file names
+const maxAudioNameLength = 50
+
+// SaveAudio writes the audio of text spoken in lang to dir, naming the file
+// after both, and returns the path of the written file
+func SaveAudio(dir, lang, text string, audio []byte) (string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, audioFileName(lang, text, audio))
+	return path, ioutil.WriteFile(path, audio, 0o644)
+}
+
+// audioFileName returns a file name safe on every platform like lang-some-text.mp3
+func audioFileName(lang, text string, audio []byte) string {
+	var b strings.Builder
+	b.WriteString(lang)
+
+	n, dash := 0, true
+	for _, r := range strings.ToLower(text) {
+		if n == maxAudioNameLength {
+			break
+		}
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if dash {
+				b.WriteRune('-')
+				dash = false
+			}
+			b.WriteRune(r)
+			n++
+		} else {
+			dash = true
+		}
+	}
+	return b.String() + AudioExtension(audio)
+}
+
+// AudioExtension returns the extension of the format of audio: .wav for the
+// local speech engines, .mp3 for the backends
+func AudioExtension(audio []byte) string {
+	if isWAV(audio) {
+		return ".wav"
+	}
+	return ".mp3"
+}
@@ -0,0 +1,40 @@
+package model
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAudioFileName(t *testing.T) {
+	testCases := []struct {
+		lang, text string
+		audio      []byte
+		want       string
+	}{
+		{"it", "Buongiorno, a tutti!", []byte("mp3"), "it-buongiorno-a-tutti.mp3"},
+		{"ja", "おはよう", testWAV(), "ja-おはよう.wav"},
+		{"en", "../../etc/passwd", nil, "en-etc-passwd.mp3"},
+		{"en", strings.Repeat("a", 60), nil, "en-" + strings.Repeat("a", maxAudioNameLength) + ".mp3"},
+	}
+	for _, tc := range testCases {
+		if got := audioFileName(tc.lang, tc.text, tc.audio); got != tc.want {
+			t.Errorf("audioFileName(%q, %q) = %q, want %q", tc.lang, tc.text, got, tc.want)
+		}
+	}
+}
+
+func TestSaveAudio(t *testing.T) {
+	m, speech, _ := newTestModel(t)
+
+	press(m, "s")
+	path := filepath.Join(m.conf.AudioDir(), "it-buongiorno.wav")
+	if data, err := os.ReadFile(path); err != nil || !bytes.Equal(data, speech.audio) {
+		t.Fatalf("expected the audio saved to %s: %v", path, err)
+	}
+	if m.state != TRANSLATING || !strings.Contains(m.status, path) {
+		t.Errorf("expected the path in the footer, got %q", m.status)
+	}
+}
@@ -0,0 +1,83 @@
+package model
+
+import (
+	"context"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/fedeztk/got/pkg/translator"
+	"github.com/fedeztk/got/pkg/translator/cache"
+	"github.com/fedeztk/got/pkg/translator/glossary"
+)
+
+// compareHelp is shown in the compare tab before the first comparison
+const compareHelp = "  Press enter to translate the text with every engine listed under compare in the config file"
+
+type gotComparison struct {
+	Err     error
+	request int // id of the request the message answers
+	result  string
+}
+
+// newBackend returns the backend called name set up from c, cached if enabled
+// and enforcing the glossaries
+func newBackend(c Config, name string) (translator.Backend, error) {
+	backend, err := translator.NewBackend(name, translator.Options{
+		APIKey:    c.APIKey(),
+		Instances: c.BackendInstances(name),
+		Timeout:   c.Timeout(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if c.UseCache() {
+		backend = cache.New(backend, name, cache.Options{
+			TTL:          c.CacheTTL(),
+			MaxSize:      c.CacheMaxSize(),
+			AudioMaxSize: c.AudioCacheMaxSize(),
+		})
+	}
+	return glossary.NewBackend(backend, glossary.NewSet(c.Glossaries())), nil
+}
+
+// newCompareBackends returns the backends of the compared engines, reusing
+// the current backend
+func newCompareBackends(c Config, current translator.Backend, engines []translator.Engine) (map[string]translator.Backend, error) {
+	backends := map[string]translator.Backend{c.Backend(): current}
+	for _, e := range engines {
+		if _, ok := backends[e.Backend]; ok {
+			continue
+		}
+		b, err := newBackend(c, e.Backend)
+		if err != nil {
+			return nil, err
+		}
+		backends[e.Backend] = b
+	}
+	return backends, nil
+}
+
+// compareQuery returns the text to compare: the one being typed, or the one
+// last translated
+func (m model) compareQuery() string {
+	if query := strings.TrimSpace(m.textInput.Value()); query != "" {
+		return query
+	}
+	return m.query
+}
+
+// fetchComparison translates query with every compared engine, to the first target language
+func (m model) fetchComparison(ctx context.Context, query string) tea.Cmd {
+	// switching engine adds to the backends of the model while comparing
+	backends := make(map[string]translator.Backend, len(m.compareBackends))
+	for name, b := range m.compareBackends {
+		backends[name] = b
+	}
+	return func() tea.Msg {
+		comparisons := translator.Compare(ctx, backends, m.compareEngines, query, m.source, m.targets[0])
+		if err := ctx.Err(); err != nil {
+			return gotComparison{Err: err, request: m.request}
+		}
+		return gotComparison{result: translator.PrettyPrintComparisons(comparisons), request: m.request}
+	}
+}
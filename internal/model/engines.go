@@ -0,0 +1,63 @@
+package model
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/fedeztk/got/pkg/translator"
+	"github.com/fedeztk/got/pkg/tts"
+)
+
+// cycleEngine switches to the next (or previous with a negative direction)
+// engine of translator.AllEngines, changing backend when needed
+func (m *model) cycleEngine(direction int) tea.Cmd {
+	engines := translator.AllEngines()
+	current := -1
+	for i, e := range engines {
+		if e.Backend == m.conf.Backend() && e.Engine == m.conf.Engine() {
+			current = i
+			break
+		}
+	}
+	if current < 0 && direction < 0 {
+		current = 0
+	}
+	next := engines[(current+direction+len(engines))%len(engines)]
+	return m.switchEngine(next)
+}
+
+// switchEngine makes e the engine of the next translations, replacing the
+// backend if e belongs to another one, and persists the choice
+func (m *model) switchEngine(e translator.Engine) tea.Cmd {
+	if err := translator.CheckEngine(e.Backend, e.Engine); err != nil {
+		m.status = ErrorStyle.Render(err.Error())
+		return nil
+	}
+
+	var cmd tea.Cmd
+	if e.Backend != m.conf.Backend() {
+		backend, ok := m.compareBackends[e.Backend]
+		if !ok {
+			var err error
+			if backend, err = newBackend(m.conf, e.Backend); err != nil {
+				m.status = ErrorStyle.Render("Unable to switch backend: " + err.Error())
+				return nil
+			}
+			m.compareBackends[e.Backend] = backend
+		}
+		speech, err := tts.WithFallback(backend, m.conf.TTSEngines())
+		if err != nil {
+			m.status = ErrorStyle.Render("Unable to switch backend: " + err.Error())
+			return nil
+		}
+
+		m.backend, m.tts = backend, speech
+		m.conf.SetBackend(e.Backend)
+		cmd = m.fetchLanguages() // every backend supports different languages
+	}
+	m.conf.SetEngine(e.Engine)
+	m.conf.RememberLastSettings(m.source, strings.Join(m.targets, ","))
+
+	m.status = footerTextStyle.Render("Switched to the " + e.Engine + " engine of " + e.Backend)
+	return cmd
+}
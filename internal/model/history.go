@@ -0,0 +1,51 @@
+package model
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/fedeztk/got/internal/history"
+	"github.com/fedeztk/got/pkg/translator/utils"
+)
+
+type historyItem struct {
+	history.Entry
+}
+
+func (i historyItem) Title() string { return i.Query }
+func (i historyItem) Description() string {
+	return fmt.Sprintf("%s → %s: %s (%s)", i.Source, i.Target, i.Result, i.Time.Format("2006-01-02 15:04"))
+}
+func (i historyItem) FilterValue() string { return i.Query + " " + i.Result }
+
+func newHistoryList(entries []history.Entry) list.Model {
+	items := make([]list.Item, 0, len(entries))
+	for _, e := range entries {
+		items = append(items, historyItem{e})
+	}
+
+	l := list.New(items, list.NewDefaultDelegate(), 0, 0)
+	l.DisableQuitKeybindings()
+	l.SetShowHelp(false)
+	l.Title = "History"
+	l.AdditionalFullHelpKeys = getHistoryAdditionalKeyMap
+	l.Styles.Title = titleStyle
+	return l
+}
+
+// openHistoryEntry shows the result of a past translation in the translation tab
+func (m *model) openHistoryEntry(e history.Entry) {
+	m.err = nil
+	m.source, m.targets, m.shortTarget = e.Source, []string{e.Target}, e.Target
+	m.markTargets()
+	m.query, m.shortResult, m.detected, m.cached = e.Query, e.Result, "", false
+	m.response = e.Response
+	if e.Response != nil {
+		m.result = e.Response.PrettyPrint()
+		m.detected = e.Response.Detected
+	} else {
+		m.result = utils.Result{Translation: e.Result}.PrettyPrint()
+	}
+	m.viewport.SetContent(m.result)
+	m.setState(TRANSLATING)
+}
@@ -10,7 +10,7 @@ type keyBindingMgr struct {
 }
 
 func (kbm keyBindingMgr) ShortHelp() []key.Binding {
-	if kbm.state != CHOOSING {
+	if !hasFullHelp(kbm.state) {
 		return append(globalKeyMap, kbm.Bindings[kbm.state]...)
 	}
 	return append(globalKeyMap, key.NewBinding(
@@ -18,37 +18,53 @@ func (kbm keyBindingMgr) ShortHelp() []key.Binding {
 		key.WithHelp("?", "toggle full help")))
 }
 
-// only used when in CHOOSING state
+// hasFullHelp reports whether the help of state is toggled with ?, for states with many keys
+func hasFullHelp(state int) bool {
+	return state == CHOOSING || state == TRANSLATING || state == HISTORY || state == PHRASEBOOK
+}
+
+// only used when in the states of hasFullHelp
 func (kbm keyBindingMgr) FullHelp() [][]key.Binding {
 	keys := append(globalKeyMap, kbm.Bindings[kbm.state]...)
 	// group them 2 per line
 	groups := [][]key.Binding{}
 	for i := 0; i < len(keys); i += 2 {
-		groups = append(groups, keys[i:i+2])
+		end := i + 2
+		if end > len(keys) {
+			end = len(keys)
+		}
+		groups = append(groups, keys[i:end])
 	}
 	return groups
 }
 
-func newKeyBindingMgr(listKeyMaps [][]key.Binding) keyBindingMgr {
+func newKeyBindingMgr(listKeyMaps, historyKeyMaps, phrasebookKeyMaps [][]key.Binding) keyBindingMgr {
 	gbm := keyBindingMgr{
-		Bindings: make(map[int][]key.Binding, 4),
+		Bindings: make(map[int][]key.Binding, 7),
 	}
 	gbm.Bindings[TYPING] = typingKeyMap
-	gbm.Bindings[LOADING] = gbm.Bindings[TYPING]  // no particular keys for loading
+	gbm.Bindings[LOADING] = loadingKeyMap
 	gbm.Bindings[TRANSLATING] = translatingKeyMap // provided by the list component
+	gbm.Bindings[COMPARING] = comparingKeyMap
+
+	// get keys from bubbles.list components
+	gbm.Bindings[CHOOSING] = enabledKeys(listKeyMaps)
+	gbm.Bindings[HISTORY] = enabledKeys(historyKeyMaps)
+	gbm.Bindings[PHRASEBOOK] = enabledKeys(phrasebookKeyMaps)
+
+	return gbm
+}
 
-	// get keys from bubbles.list component
-	listMapping := []key.Binding{}
-	for _, list := range listKeyMaps {
+func enabledKeys(keyMaps [][]key.Binding) []key.Binding {
+	mapping := []key.Binding{}
+	for _, list := range keyMaps {
 		for _, k := range list {
 			if k.Enabled() {
-				listMapping = append(listMapping, k)
+				mapping = append(mapping, k)
 			}
 		}
 	}
-	gbm.Bindings[CHOOSING] = listMapping
-
-	return gbm
+	return mapping
 }
 
 var (
@@ -61,6 +77,10 @@ var (
 			key.WithKeys("shift+tab"),
 			key.WithHelp("shift-tab", "previous tab"),
 		),
+		key.NewBinding(
+			key.WithKeys("ctrl+n", "ctrl+p"),
+			key.WithHelp("ctrl+n/p", "next/previous engine"),
+		),
 		key.NewBinding(
 			key.WithKeys("esc", "ctrl+c"),
 			key.WithHelp("esc/ctrl+c", "exit"),
@@ -74,6 +94,13 @@ var (
 		),
 	}
 
+	loadingKeyMap = []key.Binding{
+		key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "cancel"),
+		),
+	}
+
 	translatingKeyMap = []key.Binding{
 		key.NewBinding(
 			key.WithKeys("y"),
@@ -83,6 +110,37 @@ var (
 			key.WithKeys("p"),
 			key.WithHelp("p", "play translation"),
 		),
+		key.NewBinding(
+			key.WithKeys("P"),
+			key.WithHelp("P", "play source text"),
+		),
+		key.NewBinding(
+			key.WithKeys("r"),
+			key.WithHelp("r", "replay"),
+		),
+		key.NewBinding(
+			key.WithKeys("x"),
+			key.WithHelp("x", "stop"),
+		),
+		key.NewBinding(
+			key.WithKeys("v"),
+			key.WithHelp("v", "slow playback"),
+		),
+		key.NewBinding(
+			key.WithKeys("s"),
+			key.WithHelp("s", "save audio"),
+		),
+		key.NewBinding(
+			key.WithKeys("*"),
+			key.WithHelp("*", "star/unstar"),
+		),
+	}
+
+	comparingKeyMap = []key.Binding{
+		key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "compare engines"),
+		),
 	}
 
 	getListAdditionalKeyMap = func() []key.Binding {
@@ -95,10 +153,40 @@ var (
 				key.WithKeys("t"),
 				key.WithHelp("t", "choose target language"),
 			),
+			key.NewBinding(
+				key.WithKeys("a"),
+				key.WithHelp("a", "add/remove target language"),
+			),
 			key.NewBinding(
 				key.WithKeys("i"),
 				key.WithHelp("i", "invert languages"),
 			),
 		}
 	}
+
+	getHistoryAdditionalKeyMap = func() []key.Binding {
+		return []key.Binding{
+			key.NewBinding(
+				key.WithKeys("enter"),
+				key.WithHelp("enter", "open translation"),
+			),
+			key.NewBinding(
+				key.WithKeys("r"),
+				key.WithHelp("r", "translate again"),
+			),
+		}
+	}
+
+	getPhrasebookAdditionalKeyMap = func() []key.Binding {
+		return []key.Binding{
+			key.NewBinding(
+				key.WithKeys("enter"),
+				key.WithHelp("enter", "open translation"),
+			),
+			key.NewBinding(
+				key.WithKeys("x", "delete"),
+				key.WithHelp("x", "delete"),
+			),
+		}
+	}
 )
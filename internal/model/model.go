@@ -1,12 +1,11 @@
 package model
 
 import (
-	"bytes"
+	"context"
+	"errors"
 	"fmt"
-	"io/ioutil"
 	"os"
 	"strings"
-	"sync"
 	"time"
 
 	"github.com/atotto/clipboard"
@@ -17,11 +16,13 @@ import (
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
-	"github.com/faiface/beep"
-	"github.com/faiface/beep/mp3"
-	"github.com/faiface/beep/speaker"
+	"github.com/fedeztk/got/internal/history"
+	"github.com/fedeztk/got/internal/phrasebook"
 	"github.com/fedeztk/got/pkg/translator"
+	"github.com/fedeztk/got/pkg/translator/cache"
+	"github.com/fedeztk/got/pkg/translator/glossary"
 	"github.com/fedeztk/got/pkg/translator/utils"
+	"github.com/fedeztk/got/pkg/tts"
 )
 
 const (
@@ -29,44 +30,84 @@ const (
 	TYPING      = iota // input tab
 	CHOOSING           // language list tab
 	TRANSLATING        // translation tab
+	COMPARING          // engines comparison tab
+	HISTORY            // history list tab
+	PHRASEBOOK         // starred translations tab
 	LOADING            // loading inside input tab
 	// pager
 	headerHeight = 6 // 3 + 3 tabs and gaps
 	footerHeight = 3
 )
 
-var once sync.Once
-
 type model struct {
 	textInput textinput.Model
 	spinner   spinner.Model
 	viewport  viewport.Model
+	compView  viewport.Model
 	langList  list.Model
+	histList  list.Model
 	help      help.Model
 
+	phraseList list.Model
+
 	keyMgr keyBindingMgr
 
+	query       string // text of the current translation
 	result      string
+	response    *utils.Result // full result of shortResult, if known
 	shortResult string
+	detected    string // source language detected by the backend, if any
+	cached      bool   // whether the result was served from the cache
+	status      string // feedback about the last action, shown in the footer
+	shortTarget string // language of shortResult
 	source      string
-	target      string
+	targets     []string
 
 	termInfoReady bool
 	state         int
+	prevState     int                // state to go back to when loading is cancelled
+	cancel        context.CancelFunc // cancels the in-flight request, if any
+	request       int                // id of the last request, the messages of the previous ones are stale
 	err           error
 	conf          Config
 	backend       translator.Backend
+	tts           tts.Provider // the backend, falling back to the local speech engines
+	history       *history.Store
+	player        *player
+	glossaries    *glossary.Set // to highlight the enforced terms
+	phrasebook    *phrasebook.Store
+
+	compareEngines  []translator.Engine
+	compareBackends map[string]translator.Backend // by name, for every compared engine
 }
 
 type gotTrans struct {
 	Err         error
+	request     int // id of the request the message answers
+	query       string
 	result      string
 	shortResult string
+	shortTarget string
+	detected    string
+	cached      bool
+	entries     []history.Entry
+}
+
+type gotLangs struct {
+	Err       error
+	languages []utils.Language
 }
 
 type gotTTS struct {
-	Err    error
-	result []byte
+	Err     error
+	request int // id of the request the message answers
+	result  []byte
+}
+
+type savedTTS struct {
+	Err     error
+	request int // id of the request the message answers
+	path    string
 }
 
 type Config interface {
@@ -74,6 +115,21 @@ type Config interface {
 	Target() string
 	Engine() string
 	Backend() string
+	APIKey() string
+	Instances() []string
+	Timeout() time.Duration
+	CacheTTL() time.Duration
+	CacheMaxSize() int64
+	AudioCacheMaxSize() int64
+	UseCache() bool
+	TTSEngines() map[string]string
+	Glossaries() map[string]string
+	Phrasebook() string
+	AudioDir() string
+	BackendInstances(backend string) []string
+	CompareEngines() []string
+	SetBackend(backend string)
+	SetEngine(engine string)
 	RememberLastSettings(source, target string)
 }
 
@@ -88,30 +144,68 @@ func newModel(c Config) *model {
 	s.Spinner = spinner.Dot
 	s.Style = spinnerStyle
 
-	l := list.New(getConfLangs(), list.NewDefaultDelegate(), 0, 0)
+	targets := translator.ParseTargets(c.Target())
+
+	// replaced by the languages supported by the backend once fetched
+	l := list.New(getConfLangs(utils.GetLanguageList(), targets), list.NewDefaultDelegate(), 0, 0)
 	l.DisableQuitKeybindings()
 	l.SetShowHelp(false)
 	l.Title = "Available languages"
 	l.AdditionalFullHelpKeys = getListAdditionalKeyMap
 	l.Styles.Title = titleStyle
 
-	backend, err := translator.NewBackend(c.Backend())
+	backend, err := newBackend(c, c.Backend())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	engines, err := translator.ParseEngines(c.CompareEngines())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	compareBackends, err := newCompareBackends(c, backend, engines)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	speech, err := tts.WithFallback(backend, c.TTSEngines())
 	if err != nil {
 		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
 
+	h := history.NewStore()
+	entries, _ := h.Entries() // a broken history just starts empty
+	hl := newHistoryList(entries)
+
+	pb := phrasebook.NewStore(c.Phrasebook())
+	phrases, _ := pb.Entries() // same as the history
+	pl := newPhrasebookList(phrases)
+
 	return &model{
 		langList:  l,
+		histList:  hl,
 		textInput: t,
 		spinner:   s,
 		state:     TYPING,
 		source:    c.Source(),
-		target:    c.Target(),
+		targets:   targets,
 		help:      help.New(),
 		conf:      c,
-		keyMgr:    newKeyBindingMgr(l.FullHelp()),
+		keyMgr:    newKeyBindingMgr(l.FullHelp(), hl.FullHelp(), pl.FullHelp()),
 		backend:   backend,
+		tts:       speech,
+		history:   h,
+		player:    newPlayer(),
+
+		glossaries: glossary.NewSet(c.Glossaries()),
+		phrasebook: pb,
+		phraseList: pl,
+
+		compareEngines:  engines,
+		compareBackends: compareBackends,
 	}
 }
 
@@ -126,7 +220,7 @@ func Run(c Config) {
 }
 
 func (m model) Init() tea.Cmd {
-	return textinput.Blink
+	return tea.Batch(textinput.Blink, m.fetchLanguages())
 }
 
 func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -137,7 +231,7 @@ func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		if m.langList.FilterState() == list.Filtering {
+		if m.langList.FilterState() == list.Filtering || m.histList.FilterState() == list.Filtering || m.phraseList.FilterState() == list.Filtering {
 			break
 		}
 
@@ -149,8 +243,22 @@ func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "shift+tab":
 			m.switchTab(-1)
 
+		case "ctrl+n", "ctrl+p":
+			if m.state == LOADING { // the request in flight uses the current backend
+				break
+			}
+			if msg.String() == "ctrl+n" {
+				cmds = append(cmds, m.cycleEngine(+1))
+			} else {
+				cmds = append(cmds, m.cycleEngine(-1))
+			}
+
 		case "ctrl+c", "esc":
-			m.conf.RememberLastSettings(m.source, m.target)
+			if msg.String() == "esc" && m.state == LOADING {
+				m.cancelLoading()
+				return m, nil
+			}
+			m.conf.RememberLastSettings(m.source, strings.Join(m.targets, ","))
 			return m, tea.Quit
 		}
 
@@ -160,18 +268,55 @@ func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case "s":
 				abbreviation, title := m.langList.SelectedItem().(item).abbreviation, m.langList.SelectedItem().(item).title
 				m.source = abbreviation
+				m.detected = ""
 				statusCmd := m.langList.NewStatusMessage(statusMessageStyle.Render("Source language: " + title))
 				cmds = append(cmds, statusCmd)
 
 			case "t":
-				abbreviation, title := m.langList.SelectedItem().(item).abbreviation, m.langList.SelectedItem().(item).title
-				m.target = abbreviation
-				statusCmd := m.langList.NewStatusMessage(statusMessageStyle.Render("Target language: " + title))
+				selected := m.langList.SelectedItem().(item)
+				if selected.sourceOnly {
+					statusCmd := m.langList.NewStatusMessage(statusMessageStyle.Render(selected.title + " can only be used as source language"))
+					cmds = append(cmds, statusCmd)
+					break
+				}
+				m.targets = []string{selected.abbreviation}
+				m.markTargets()
+				statusCmd := m.langList.NewStatusMessage(statusMessageStyle.Render("Target language: " + selected.title))
 				cmds = append(cmds, statusCmd)
 
+			case "a":
+				selected := m.langList.SelectedItem().(item)
+				var status string
+				switch {
+				case selected.sourceOnly:
+					status = selected.title + " can only be used as source language"
+				case !selected.target:
+					m.targets = append(m.targets, selected.abbreviation)
+					status = "Added target language: " + selected.title
+				case len(m.targets) == 1:
+					status = "Cannot remove the only target language"
+				default:
+					m.targets = without(m.targets, selected.abbreviation)
+					status = "Removed target language: " + selected.title
+				}
+				m.markTargets()
+				cmds = append(cmds, m.langList.NewStatusMessage(statusMessageStyle.Render(status)))
+
 			case "i":
-				m.source, m.target = m.target, m.source
-				statusCmd := m.langList.NewStatusMessage(statusMessageStyle.Render("Inverted languages: " + m.source + " → " + m.target))
+				if m.source == utils.AutoDetect.Code {
+					statusCmd := m.langList.NewStatusMessage(statusMessageStyle.Render("Cannot invert languages when detecting the source language"))
+					cmds = append(cmds, statusCmd)
+					break
+				}
+				if len(m.targets) != 1 {
+					statusCmd := m.langList.NewStatusMessage(statusMessageStyle.Render("Cannot invert languages with several target languages"))
+					cmds = append(cmds, statusCmd)
+					break
+				}
+				m.source, m.targets = m.targets[0], []string{m.source}
+				m.detected = ""
+				m.markTargets()
+				statusCmd := m.langList.NewStatusMessage(statusMessageStyle.Render("Inverted languages: " + m.source + " → " + m.targets[0]))
 				cmds = append(cmds, statusCmd)
 
 			case "?":
@@ -179,15 +324,49 @@ func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+		// history list keybindings
+		if m.state == HISTORY {
+			if selected, ok := m.histList.SelectedItem().(historyItem); ok {
+				switch msg.String() {
+				case "enter":
+					m.openHistoryEntry(selected.Entry)
+				case "r":
+					m.source, m.targets = selected.Source, []string{selected.Target}
+					m.markTargets()
+					m.textInput.SetValue(selected.Query)
+					ctx := m.startLoading()
+					cmds = append(cmds, spinner.Tick)
+					cmds = append(cmds, m.fetchTranslation(ctx, selected.Query))
+				}
+			}
+			if msg.String() == "?" {
+				m.help.ShowAll = !m.help.ShowAll
+			}
+		}
+
+		// phrasebook list keybindings
+		if m.state == PHRASEBOOK {
+			switch msg.String() {
+			case "enter":
+				if selected, ok := m.phraseList.SelectedItem().(phraseItem); ok {
+					m.openPhrase(selected.Entry)
+				}
+			case "x", "delete":
+				cmds = append(cmds, m.deletePhrase())
+			case "?":
+				m.help.ShowAll = !m.help.ShowAll
+			}
+		}
+
 		// text input keybindings
 		if m.state == TYPING {
 			switch msg.String() {
 			case "enter":
 				query := strings.TrimSpace(m.textInput.Value())
 				if query != "" {
-					m.setState(LOADING)
+					ctx := m.startLoading()
 					cmds = append(cmds, spinner.Tick)
-					cmds = append(cmds, m.fetchTranslation(query))
+					cmds = append(cmds, m.fetchTranslation(ctx, query))
 				}
 			}
 		}
@@ -198,9 +377,45 @@ func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case "y":
 				m.yankTranslated()
 			case "p":
-				m.setState(LOADING)
+				ctx := m.startLoading()
+				cmds = append(cmds, spinner.Tick)
+				cmds = append(cmds, m.fetchTextToSpeech(ctx, m.shortResult, m.shortTarget))
+			case "P":
+				ctx := m.startLoading()
 				cmds = append(cmds, spinner.Tick)
-				cmds = append(cmds, m.fetchTextToSpeech(m.shortResult))
+				cmds = append(cmds, m.fetchTextToSpeech(ctx, m.query, m.sourceLanguage()))
+			case "s":
+				ctx := m.startLoading()
+				cmds = append(cmds, spinner.Tick)
+				cmds = append(cmds, m.saveTextToSpeech(ctx, m.shortResult))
+			case "r":
+				playCmd, err := m.player.Replay()
+				m.setPlaybackStatus(err)
+				cmds = append(cmds, playCmd)
+			case "x":
+				m.player.Stop()
+			case "*":
+				cmds = append(cmds, m.toggleStar())
+			case "v":
+				if m.player.ToggleSlow() {
+					m.status = footerTextStyle.Render("Slow playback")
+				} else {
+					m.status = footerTextStyle.Render("Normal playback")
+				}
+			case "?":
+				m.help.ShowAll = !m.help.ShowAll
+			}
+		}
+
+		// compare keybindings
+		if m.state == COMPARING {
+			switch msg.String() {
+			case "enter":
+				if query := m.compareQuery(); query != "" {
+					ctx := m.startLoading()
+					cmds = append(cmds, spinner.Tick)
+					cmds = append(cmds, m.fetchComparison(ctx, query))
+				}
 			}
 		}
 
@@ -211,31 +426,105 @@ func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// update pager
 		if !m.termInfoReady { // first time receiving terminal size, we don't have a viewport yet
 			m.viewport = viewport.Model{Width: msg.Width, Height: msg.Height - verticalMargins}
+			m.compView = viewport.Model{Width: msg.Width, Height: msg.Height - verticalMargins}
+			m.compView.SetContent(compareHelp)
 			m.termInfoReady = true
 		} else { // resize according to the new terminal size
 			m.viewport.Width = msg.Width
 			m.viewport.Height = msg.Height - verticalMargins
+			m.compView.Width = msg.Width
+			m.compView.Height = msg.Height - verticalMargins
 		}
 
 		// update language list
 		m.langList.SetWidth(msg.Width)
 		m.langList.SetHeight(msg.Height - verticalMargins)
 
+		// update history list
+		m.histList.SetWidth(msg.Width)
+		m.histList.SetHeight(msg.Height - verticalMargins)
+
+		// update phrasebook list
+		m.phraseList.SetWidth(msg.Width)
+		m.phraseList.SetHeight(msg.Height - verticalMargins)
+
 		m.help.Width = msg.Width
 
 	// translation fetched
 	case gotTrans:
+		if msg.request != m.request || errors.Is(msg.Err, context.Canceled) { // stale or aborted by the user
+			break
+		}
+		m.stopLoading()
 		m.setState(TRANSLATING)
 		m.err = msg.Err
+		m.query = msg.query
 		m.result = msg.result
 		m.shortResult = msg.shortResult
+		m.shortTarget = msg.shortTarget
+		m.detected = msg.detected
+		m.cached = msg.cached
+		m.status = ""
+		m.response = nil
+		if len(msg.entries) > 0 {
+			m.response = msg.entries[0].Response
+		}
 		m.viewport.SetContent(m.result)
+		for i := len(msg.entries) - 1; i >= 0; i-- { // the first target ends up on top
+			cmds = append(cmds, m.histList.InsertItem(0, historyItem{msg.entries[i]}))
+		}
+
+	// translations of the compared engines fetched
+	case gotComparison:
+		if msg.request != m.request || errors.Is(msg.Err, context.Canceled) { // stale or aborted by the user
+			break
+		}
+		m.stopLoading()
+		m.setState(COMPARING)
+		if msg.Err != nil {
+			m.compView.SetContent(ErrorStyle.Render(msg.Err.Error()))
+		} else {
+			m.compView.SetContent(msg.result)
+		}
+
+	// languages supported by the backend fetched
+	case gotLangs:
+		cmds = append(cmds, m.langList.SetItems(getConfLangs(msg.languages, m.targets)))
+		if msg.Err != nil {
+			statusCmd := m.langList.NewStatusMessage(ErrorStyle.Render("Unable to fetch languages: " + msg.Err.Error()))
+			cmds = append(cmds, statusCmd)
+		}
 
 	// text to speech fetched
 	case gotTTS:
+		if msg.request != m.request || errors.Is(msg.Err, context.Canceled) { // stale or aborted by the user
+			break
+		}
+		m.stopLoading()
 		m.setState(TRANSLATING)
-		m.err = msg.Err
-		go m.playTTS(msg.result)
+		if msg.Err != nil {
+			m.status = ErrorStyle.Render("Unable to fetch audio: " + msg.Err.Error())
+			break
+		}
+		playCmd, err := m.player.Play(msg.result)
+		m.setPlaybackStatus(err)
+		cmds = append(cmds, playCmd)
+
+	// audio stopped, refresh the playing indicator
+	case playbackDone:
+
+	// text to speech written to a file
+	case savedTTS:
+		if msg.request != m.request || errors.Is(msg.Err, context.Canceled) { // stale or aborted by the user
+			break
+		}
+		m.stopLoading()
+		m.setState(TRANSLATING)
+		if msg.Err != nil {
+			m.status = ErrorStyle.Render("Unable to save audio: " + msg.Err.Error())
+		} else {
+			m.status = footerTextStyle.Render("Audio saved to " + msg.path)
+		}
 	}
 
 	switch m.state {
@@ -245,8 +534,14 @@ func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.spinner, cmd = m.spinner.Update(msg)
 	case TRANSLATING:
 		m.viewport, cmd = m.viewport.Update(msg)
+	case COMPARING:
+		m.compView, cmd = m.compView.Update(msg)
 	case CHOOSING:
 		m.langList, cmd = m.langList.Update(msg)
+	case HISTORY:
+		m.histList, cmd = m.histList.Update(msg)
+	case PHRASEBOOK:
+		m.phraseList, cmd = m.phraseList.Update(msg)
 	}
 	if cmd != nil {
 		cmds = append(cmds, cmd)
@@ -268,6 +563,16 @@ func (m model) View() string {
 
 	tabsRow = lipgloss.JoinHorizontal(lipgloss.Top, m.renderTabs()...)
 
+	height := m.viewport.Height + headerHeight + footerHeight // total height of terminal as originally received
+	footer := m.renderFooter()
+	// the full help menu can take more lines than the footer has, shrink the content to make room
+	if extra := lipgloss.Height(footer) - footerHeight; extra > 0 {
+		m.viewport.Height -= extra
+		m.langList.SetHeight(m.langList.Height() - extra)
+		m.histList.SetHeight(m.histList.Height() - extra)
+		m.phraseList.SetHeight(m.phraseList.Height() - extra)
+	}
+
 	switch m.state {
 	case TYPING:
 		content = promptStyleUpperText.Render("Enter sentence") + "\n\n" + m.textInput.View()
@@ -279,12 +584,26 @@ func (m model) View() string {
 		} else {
 			content = m.viewport.View()
 		}
+	case COMPARING:
+		content = m.compView.View()
 	case CHOOSING:
 		content = m.langList.View()
+	case HISTORY:
+		content = m.histList.View()
+	case PHRASEBOOK:
+		content = m.phraseList.View()
 	}
 
 	// holds top right translation info
-	translationStatus := promptStyleSelLang.Render(fmt.Sprintf("%s → %s (%s engine)", m.source, m.target, m.conf.Engine()))
+	source := m.source
+	if m.detected != "" && m.detected != m.source {
+		source = fmt.Sprintf("%s (detected %s)", m.source, m.detected)
+	}
+	engine := m.conf.Backend() + ", " + m.conf.Engine() + " engine"
+	if m.cached && m.state == TRANSLATING {
+		engine += ", cached"
+	}
+	translationStatus := promptStyleSelLang.Render(fmt.Sprintf("%s → %s (%s)", source, strings.Join(m.targets, ", "), engine))
 
 	lenTabs := lipgloss.Width(translationStatus) + lipgloss.Width(tabsRow) + 2 // still don't know why 2 cells are missing
 
@@ -294,29 +613,128 @@ func (m model) View() string {
 	view := tabsRow + "\n\n\n" + content
 
 	return view + lipgloss.PlaceVertical(
-		(m.viewport.Height+headerHeight+footerHeight)- // total height of terminal as originally received
-			lipgloss.Height(view), // height of already utilized space
+		height-lipgloss.Height(view), // height of already utilized space
 		lipgloss.Bottom,
-		m.renderFooter())
+		footer)
+}
+
+// fetchTranslation translates query to every target language, several
+// targets are shown in stacked sections
+func (m model) fetchTranslation(ctx context.Context, query string) tea.Cmd {
+	return func() tea.Msg {
+		results := translator.TranslateTargets(ctx, m.backend, query, m.source, m.targets, m.conf.Engine(), translator.MaxWorkers)
+		if err := ctx.Err(); err != nil {
+			return gotTrans{Err: err, request: m.request}
+		}
+
+		msg := gotTrans{query: query, cached: true, request: m.request}
+		for i, r := range results {
+			if r.Err != nil {
+				if msg.Err == nil {
+					msg.Err = r.Err
+				}
+				continue
+			}
+			entry := history.Entry{
+				Query:   query,
+				Source:  m.source,
+				Target:  r.Target,
+				Backend: m.conf.Backend(),
+				Engine:  m.conf.Engine(),
+				Result:  r.Response.ShortTranslatedText(),
+				Time:    time.Now(),
+			}
+			result := r.Response.Result()
+			entry.Response = &result
+			m.history.Add(entry) // losing an entry is not worth failing the translation
+			msg.entries = append(msg.entries, entry)
+
+			if msg.shortTarget == "" {
+				msg.shortResult, msg.shortTarget = entry.Result, r.Target
+				msg.detected = r.Response.DetectedLanguage()
+			}
+			msg.cached = msg.cached && cache.Hit(r.Response)
+			results[i].Response = m.highlightGlossary(query, r.Target, r.Response)
+		}
+
+		msg.cached = msg.cached && len(msg.entries) > 0
+		switch {
+		case len(results) > 1:
+			msg.Err = nil // failed targets are shown in their sections
+			msg.result = translator.PrettyPrintTargets(results)
+		case msg.Err == nil:
+			msg.result = results[0].Response.PrettyPrint()
+		}
+		return msg
+	}
 }
 
-func (m model) fetchTranslation(query string) tea.Cmd {
+// highlightGlossary returns r with the glossary terms of query highlighted
+func (m model) highlightGlossary(query, target string, r utils.BackendResponse) utils.BackendResponse {
+	source := m.source
+	if detected := r.DetectedLanguage(); source == "auto" && detected != "" {
+		source = detected
+	}
+	g, err := m.glossaries.Get(source, target)
+	if err != nil || g == nil { // a broken glossary already failed the translation
+		return r
+	}
+	return glossary.Highlight(r, g.Hits(query))
+}
+
+func (m model) fetchLanguages() tea.Cmd {
 	return func() tea.Msg {
-		response, err := m.backend.Translate(query, m.source, m.target, m.conf.Engine())
+		name := m.conf.Backend()
+		languages, err := translator.Languages(context.Background(), m.backend, name, m.conf.BackendInstances(name))
+		return gotLangs{Err: err, languages: languages}
+	}
+}
+
+// fetchTextToSpeech fetches the audio of query spoken in lang, detecting the
+// language of query when lang is empty
+func (m model) fetchTextToSpeech(ctx context.Context, query, lang string) tea.Cmd {
+	return func() tea.Msg {
+		if lang == "" {
+			detected, err := m.backend.Detect(ctx, query)
+			if err != nil {
+				return gotTTS{Err: err, request: m.request}
+			}
+			lang = detected
+		}
+		response, err := m.tts.TextToSpeech(ctx, query, lang)
 		if err != nil {
-			return gotTrans{Err: err, result: err.Error()}
+			return gotTTS{Err: err, request: m.request}
 		}
-		return gotTrans{result: response.PrettyPrint(), shortResult: response.ShortTranslatedText()}
+		return gotTTS{result: response, request: m.request}
 	}
 }
 
-func (m model) fetchTextToSpeech(query string) tea.Cmd {
+func (m model) saveTextToSpeech(ctx context.Context, query string) tea.Cmd {
 	return func() tea.Msg {
-		response, err := m.backend.TextToSpeech(query, m.target)
+		response, err := m.tts.TextToSpeech(ctx, query, m.shortTarget)
 		if err != nil {
-			return gotTTS{Err: err}
+			return savedTTS{Err: err, request: m.request}
 		}
-		return gotTTS{result: response}
+		path, err := SaveAudio(m.conf.AudioDir(), m.shortTarget, query, response)
+		return savedTTS{Err: err, path: path, request: m.request}
+	}
+}
+
+// sourceLanguage returns the language of the text that was translated,
+// empty when it was detected by the backend without reporting it
+func (m model) sourceLanguage() string {
+	if m.source == utils.AutoDetect.Code {
+		return m.detected
+	}
+	return m.source
+}
+
+// setPlaybackStatus reports in the footer why the audio could not be played
+func (m *model) setPlaybackStatus(err error) {
+	if err != nil {
+		m.status = ErrorStyle.Render("Unable to play audio: " + err.Error())
+	} else {
+		m.status = ""
 	}
 }
 
@@ -325,8 +743,35 @@ func (m *model) setState(state int) {
 	m.keyMgr.state = state
 }
 
+// startLoading switches to the LOADING state and returns the context of the
+// request that is about to be sent, cancelled if the user aborts loading
+func (m *model) startLoading() context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancel = cancel
+	m.request++
+	m.prevState = m.state
+	m.setState(LOADING)
+	return ctx
+}
+
+// stopLoading releases the context of a completed request
+func (m *model) stopLoading() {
+	if m.cancel != nil {
+		m.cancel()
+		m.cancel = nil
+	}
+}
+
+// cancelLoading aborts the in-flight request and goes back to the previous
+// state, its answer is dropped even if it was already on its way
+func (m *model) cancelLoading() {
+	m.stopLoading()
+	m.request++
+	m.setState(m.prevState)
+}
+
 func (m *model) switchTab(direction int) {
-	states := []int{TYPING, CHOOSING, TRANSLATING}
+	states := []int{TYPING, CHOOSING, TRANSLATING, COMPARING, HISTORY, PHRASEBOOK}
 
 	var newState int
 	if direction > 0 {
@@ -340,7 +785,7 @@ func (m *model) switchTab(direction int) {
 	} else {
 		m.textInput.Blur()
 	}
-	if newState != CHOOSING {
+	if !hasFullHelp(newState) {
 		m.help.ShowAll = false
 	}
 
@@ -366,7 +811,15 @@ func (m *model) renderFooter() string {
 		gapSize -= lipgloss.Width(percentStr) + 4
 	}
 
-	footerTop = strings.Repeat(" ", gapSize) + footerTop
+	status := m.status
+	if playing, slow := m.player.Playing(); playing {
+		indicator := "♪ playing"
+		if slow {
+			indicator += " (slow)"
+		}
+		status = footerTextStyle.Render(indicator) + " " + status
+	}
+	footerTop = status + strings.Repeat(" ", diffOrZero(gapSize, lipgloss.Width(status))) + footerTop
 	footerMid = strings.Repeat("─", gapSize) + footerMid
 	footerBot = helpMenu + strings.Repeat(" ", diffOrZero(gapSize, helpLen)) + footerStyle.Render(footerBot)
 	footer := fmt.Sprintf("%s\n%s\n%s", footerTop, footerMid, footerBot)
@@ -379,6 +832,9 @@ func (m *model) renderTabs() []string {
 		TYPING:      "Text input",
 		CHOOSING:    "Language selection",
 		TRANSLATING: "Translation",
+		COMPARING:   "Compare",
+		HISTORY:     "History",
+		PHRASEBOOK:  "Phrasebook",
 	}
 	checkActive := func(i int, title string) string {
 		if i == m.state {
@@ -394,39 +850,64 @@ func (m *model) renderTabs() []string {
 	return s
 }
 
-func (m *model) playTTS(audio []byte) {
-	streamer, format, _ := mp3.Decode(ioutil.NopCloser(bytes.NewReader(audio)))
-	defer streamer.Close()
-
-	once.Do(func() {
-		speaker.Init(format.SampleRate, format.SampleRate.N(time.Second/10))
-	})
-
-	done := make(chan bool)
-	speaker.Play(beep.Seq(streamer, beep.Callback(func() {
-		done <- true
-	})))
-
-	<-done
-}
-
 type item struct {
 	title, abbreviation string
+	sourceOnly          bool
+	target              bool // whether it is one of the target languages
 }
 
-func (i item) Title() string       { return i.title }
-func (i item) Description() string { return i.abbreviation }
+func (i item) Title() string { return i.title }
+func (i item) Description() string {
+	switch {
+	case i.sourceOnly:
+		return i.abbreviation + " (source only)"
+	case i.target:
+		return i.abbreviation + " (target)"
+	}
+	return i.abbreviation
+}
 func (i item) FilterValue() string { return i.title }
 
-func getConfLangs() []list.Item {
-	items := make([]list.Item, 0)
+func getConfLangs(languages []utils.Language, targets []string) []list.Item {
+	items := make([]list.Item, 0, len(languages))
 
-	for abbrev, title := range utils.GetAllLanguages() {
-		items = append(items, item{title, abbrev})
+	for _, l := range languages {
+		items = append(items, item{title: l.Name, abbreviation: l.Code, sourceOnly: l.SourceOnly, target: contains(targets, l.Code)})
 	}
 	return items
 }
 
+// markTargets marks the target languages in the language list
+func (m *model) markTargets() {
+	for i, li := range m.langList.Items() {
+		it := li.(item)
+		if target := contains(m.targets, it.abbreviation); target != it.target {
+			it.target = target
+			m.langList.SetItem(i, it)
+		}
+	}
+}
+
+func contains(list []string, s string) bool {
+	for _, l := range list {
+		if l == s {
+			return true
+		}
+	}
+	return false
+}
+
+// without returns list without s
+func without(list []string, s string) []string {
+	filtered := make([]string, 0, len(list))
+	for _, l := range list {
+		if l != s {
+			filtered = append(filtered, l)
+		}
+	}
+	return filtered
+}
+
 func diffOrZero(x, y int) int {
 	if x > y {
 		return x - y
@@ -0,0 +1,120 @@
+package model
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"path/filepath"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// testConfig is a configuration keeping every file in a temporary directory
+type testConfig struct {
+	dir string
+}
+
+func (c testConfig) Source() string                             { return "en" }
+func (c testConfig) Target() string                             { return "it" }
+func (c testConfig) Engine() string                             { return "google" }
+func (c testConfig) Backend() string                            { return "lingvatranslate" }
+func (c testConfig) APIKey() string                             { return "" }
+func (c testConfig) Instances() []string                        { return nil }
+func (c testConfig) Timeout() time.Duration                     { return time.Second }
+func (c testConfig) CacheTTL() time.Duration                    { return time.Hour }
+func (c testConfig) CacheMaxSize() int64                        { return 0 }
+func (c testConfig) AudioCacheMaxSize() int64                   { return 0 }
+func (c testConfig) UseCache() bool                             { return false }
+func (c testConfig) TTSEngines() map[string]string              { return nil }
+func (c testConfig) Glossaries() map[string]string              { return nil }
+func (c testConfig) Phrasebook() string                         { return filepath.Join(c.dir, "phrasebook.jsonl") }
+func (c testConfig) AudioDir() string                           { return filepath.Join(c.dir, "audio") }
+func (c testConfig) BackendInstances(backend string) []string   { return nil }
+func (c testConfig) CompareEngines() []string                   { return []string{"lingvatranslate"} }
+func (c testConfig) SetBackend(backend string)                  {}
+func (c testConfig) SetEngine(engine string)                    {}
+func (c testConfig) RememberLastSettings(source, target string) {}
+
+// fakeTTS speaks every text with the same audio, counting the calls
+type fakeTTS struct {
+	audio []byte
+	calls int
+}
+
+func (f *fakeTTS) TextToSpeech(ctx context.Context, text, language string) ([]byte, error) {
+	f.calls++
+	return f.audio, nil
+}
+
+// testWAV returns a short clip of silence as 16 bit mono wav
+func testWAV() []byte {
+	data := make([]byte, 2*100)
+	b := &bytes.Buffer{}
+	b.WriteString("RIFF")
+	binary.Write(b, binary.LittleEndian, uint32(36+len(data)))
+	b.WriteString("WAVEfmt ")
+	for _, v := range []interface{}{uint32(16), uint16(1), uint16(1), uint32(44100), uint32(2 * 44100), uint16(2), uint16(16)} {
+		binary.Write(b, binary.LittleEndian, v)
+	}
+	b.WriteString("data")
+	binary.Write(b, binary.LittleEndian, uint32(len(data)))
+	b.Write(data)
+	return b.Bytes()
+}
+
+// newTestModel returns a model showing the translation of "good morning",
+// speaking with a fake text to speech and playing on a fake speaker
+func newTestModel(t *testing.T) (*model, *fakeTTS, *fakeSpeaker) {
+	dir := t.TempDir()
+	t.Setenv("XDG_DATA_HOME", dir)
+
+	speech, out := &fakeTTS{audio: testWAV()}, &fakeSpeaker{}
+	m := newModel(testConfig{dir: dir})
+	m.tts, m.player = speech, &player{out: out}
+	m.query, m.shortResult, m.shortTarget = "good morning", "Buongiorno!", "it"
+	m.setState(TRANSLATING)
+	return m, speech, out
+}
+
+// press sends the key to m, then feeds back the text to speech messages of
+// the commands it returns. The end of the playback is sent to the channel
+func press(m *model, key string) <-chan playbackDone {
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+	done := make(chan playbackDone, 1)
+	run(m, cmd, done)
+	return done
+}
+
+// run runs cmd, and every command of a batch. The commands still running
+// after a while, waiting for timers or for the playback, are left running
+func run(m *model, cmd tea.Cmd, done chan<- playbackDone) {
+	if cmd == nil {
+		return
+	}
+	msgs := make(chan tea.Msg, 1)
+	go func() { msgs <- cmd() }()
+
+	var msg tea.Msg
+	select {
+	case msg = <-msgs:
+	case <-time.After(100 * time.Millisecond):
+		go func() {
+			if msg, ok := (<-msgs).(playbackDone); ok {
+				done <- msg
+			}
+		}()
+		return
+	}
+
+	switch msg := msg.(type) {
+	case tea.BatchMsg:
+		for _, c := range msg {
+			run(m, c, done)
+		}
+	case gotTTS, savedTTS:
+		_, c := m.Update(msg)
+		run(m, c, done)
+	}
+}
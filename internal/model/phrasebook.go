@@ -0,0 +1,112 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/fedeztk/got/internal/history"
+	"github.com/fedeztk/got/internal/phrasebook"
+	"github.com/fedeztk/got/pkg/translator/utils"
+)
+
+type phraseItem struct {
+	phrasebook.Entry
+}
+
+func (i phraseItem) Title() string { return i.Query }
+func (i phraseItem) Description() string {
+	description := fmt.Sprintf("%s → %s: %s", i.Source, i.Target, i.Result.Translation)
+	if i.Result.Pronunciation != "" {
+		description += " (" + i.Result.Pronunciation + ")"
+	}
+	return description
+}
+func (i phraseItem) FilterValue() string { return i.Query + " " + i.Result.Translation }
+
+func newPhrasebookList(entries []phrasebook.Entry) list.Model {
+	items := make([]list.Item, 0, len(entries))
+	for _, e := range entries {
+		items = append(items, phraseItem{e})
+	}
+
+	l := list.New(items, list.NewDefaultDelegate(), 0, 0)
+	l.DisableQuitKeybindings()
+	l.SetShowHelp(false)
+	l.Title = "Phrasebook"
+	l.AdditionalFullHelpKeys = getPhrasebookAdditionalKeyMap
+	l.Styles.Title = titleStyle
+	return l
+}
+
+// currentPhrase returns the phrasebook entry of the translation shown in the
+// translation tab, the one of the first target language
+func (m model) currentPhrase() phrasebook.Entry {
+	e := phrasebook.Entry{
+		Query:   m.query,
+		Source:  m.sourceLanguage(),
+		Target:  m.shortTarget,
+		Backend: m.conf.Backend(),
+		Result:  utils.Result{Translation: m.shortResult},
+		Time:    time.Now(),
+	}
+	if e.Source == "" { // not detected yet
+		e.Source = m.source
+	}
+	if m.response != nil {
+		e.Result = *m.response
+	}
+	return e
+}
+
+// toggleStar adds the current translation to the phrasebook, or removes it if
+// it is already there
+func (m *model) toggleStar() tea.Cmd {
+	e := m.currentPhrase()
+	for i, it := range m.phraseList.Items() {
+		if !it.(phraseItem).Same(e) {
+			continue
+		}
+		if err := m.phrasebook.Remove(e); err != nil {
+			m.status = ErrorStyle.Render("Unable to update the phrasebook: " + err.Error())
+			return nil
+		}
+		m.phraseList.RemoveItem(i)
+		m.status = footerTextStyle.Render("Removed from the phrasebook")
+		return nil
+	}
+
+	if err := m.phrasebook.Add(e); err != nil {
+		m.status = ErrorStyle.Render("Unable to update the phrasebook: " + err.Error())
+		return nil
+	}
+	m.status = footerTextStyle.Render("★ Added to the phrasebook")
+	return m.phraseList.InsertItem(0, phraseItem{e})
+}
+
+// deletePhrase removes the selected entry of the phrasebook list
+func (m *model) deletePhrase() tea.Cmd {
+	selected, ok := m.phraseList.SelectedItem().(phraseItem)
+	if !ok {
+		return nil
+	}
+	if err := m.phrasebook.Remove(selected.Entry); err != nil {
+		return m.phraseList.NewStatusMessage(ErrorStyle.Render("Unable to update the phrasebook: " + err.Error()))
+	}
+	m.phraseList.RemoveItem(m.phraseList.Index())
+	return m.phraseList.NewStatusMessage(statusMessageStyle.Render("Removed " + selected.Query))
+}
+
+// openPhrase shows a phrasebook entry in the translation tab
+func (m *model) openPhrase(e phrasebook.Entry) {
+	result := e.Result
+	m.openHistoryEntry(history.Entry{
+		Query:    e.Query,
+		Source:   e.Source,
+		Target:   e.Target,
+		Backend:  e.Backend,
+		Result:   e.Result.Translation,
+		Response: &result,
+	})
+}
@@ -0,0 +1,190 @@
+package model
+
+import (
+	"bytes"
+	"io/ioutil"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/faiface/beep"
+	"github.com/faiface/beep/mp3"
+	"github.com/faiface/beep/speaker"
+	"github.com/faiface/beep/wav"
+)
+
+const (
+	// every clip is resampled to the rate the speaker is initialized with
+	sampleRate = beep.SampleRate(44100)
+	// resampling quality, 4 is good enough for on the fly resampling
+	resampleQuality = 4
+	// speed of slowed down playback
+	slowSpeed = 0.7
+)
+
+var (
+	speakerOnce    sync.Once
+	speakerInitErr error
+)
+
+// output is where the clips are played
+type output interface {
+	Init() error
+	Play(s ...beep.Streamer)
+	Clear()
+}
+
+// systemSpeaker is the speaker of the system, shared by every player
+type systemSpeaker struct{}
+
+// Init initializes the speaker the first time audio is played
+func (systemSpeaker) Init() error {
+	speakerOnce.Do(func() {
+		speakerInitErr = speaker.Init(sampleRate, sampleRate.N(time.Second/10))
+	})
+	return speakerInitErr
+}
+
+func (systemSpeaker) Play(s ...beep.Streamer) {
+	speaker.Play(s...)
+}
+
+func (systemSpeaker) Clear() {
+	speaker.Clear()
+}
+
+// playbackDone is sent when the clip with id stops, either because it ended
+// or because it was stopped or replaced
+type playbackDone struct {
+	id int
+}
+
+// player owns the speaker and plays one text to speech clip at a time,
+// a new clip replaces the one being played
+type player struct {
+	out     output
+	mu      sync.Mutex
+	id      int           // id of the last clip played
+	audio   []byte        // last clip played, played again by replay
+	stop    chan struct{} // closed to stop the current clip
+	playing bool
+	slow    bool
+}
+
+func newPlayer() *player {
+	return &player{out: systemSpeaker{}}
+}
+
+// decode decodes either mp3 audio, returned by the backends, or wav audio,
+// returned by the local speech engines
+func decode(audio []byte) (beep.StreamSeekCloser, beep.Format, error) {
+	if isWAV(audio) {
+		return wav.Decode(bytes.NewReader(audio))
+	}
+	return mp3.Decode(ioutil.NopCloser(bytes.NewReader(audio)))
+}
+
+func isWAV(audio []byte) bool {
+	return len(audio) > 12 && string(audio[:4]) == "RIFF" && string(audio[8:12]) == "WAVE"
+}
+
+// Play decodes the audio and starts playing it, replacing the current clip.
+// The returned command waits for the playback to stop
+func (p *player) Play(audio []byte) (tea.Cmd, error) {
+	streamer, format, err := decode(audio)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.out.Init(); err != nil {
+		streamer.Close()
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.stopLocked()
+
+	p.id++
+	p.audio, p.playing = audio, true
+	id, stop, done := p.id, make(chan struct{}), make(chan struct{})
+	p.stop = stop
+
+	speed := 1.0
+	if p.slow {
+		speed = slowSpeed
+	}
+	ratio := float64(format.SampleRate) / float64(sampleRate) * speed
+	p.out.Play(beep.Seq(
+		beep.ResampleRatio(resampleQuality, ratio, streamer),
+		beep.Callback(func() { close(done) }),
+	))
+
+	return func() tea.Msg {
+		select {
+		case <-done:
+		case <-stop:
+		}
+		streamer.Close()
+
+		p.mu.Lock()
+		if p.id == id {
+			p.playing = false
+		}
+		p.mu.Unlock()
+		return playbackDone{id: id}
+	}, nil
+}
+
+// Replay plays the last clip again, it is a no-op if nothing was played yet
+func (p *player) Replay() (tea.Cmd, error) {
+	p.mu.Lock()
+	audio := p.audio
+	p.mu.Unlock()
+	if audio == nil {
+		return nil, nil
+	}
+	return p.Play(audio)
+}
+
+// Stop stops the current clip, if any
+func (p *player) Stop() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.stopLocked()
+}
+
+func (p *player) stopLocked() {
+	if !p.playing {
+		return
+	}
+	p.out.Clear()
+	close(p.stop)
+	p.playing = false
+}
+
+// ToggleSlow switches between normal and slowed down playback of the next clips
+// and reports whether playback is now slowed down
+func (p *player) ToggleSlow() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.slow = !p.slow
+	return p.slow
+}
+
+// Playing reports whether a clip is being played and whether it is slowed down
+func (p *player) Playing() (playing, slow bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.playing, p.slow
+}
+
+// PlayAudio plays the audio returned by a text to speech provider, blocking
+// until the playback ends
+func PlayAudio(audio []byte) error {
+	wait, err := newPlayer().Play(audio)
+	if err != nil {
+		return err
+	}
+	wait()
+	return nil
+}
@@ -0,0 +1,112 @@
+package model
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/faiface/beep"
+)
+
+// fakeSpeaker keeps the clips played instead of playing them
+type fakeSpeaker struct {
+	mu        sync.Mutex
+	streamers []beep.Streamer
+	plays     int
+}
+
+func (s *fakeSpeaker) Init() error {
+	return nil
+}
+
+func (s *fakeSpeaker) Play(streamers ...beep.Streamer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.streamers = append(s.streamers, streamers...)
+	s.plays++
+}
+
+func (s *fakeSpeaker) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.streamers = nil
+}
+
+// finish plays the clips to their end
+func (s *fakeSpeaker) finish() {
+	s.mu.Lock()
+	streamers := s.streamers
+	s.streamers = nil
+	s.mu.Unlock()
+
+	samples := make([][2]float64, 512)
+	for _, streamer := range streamers {
+		for {
+			if _, ok := streamer.Stream(samples); !ok {
+				break
+			}
+		}
+	}
+}
+
+// wait waits for the end of the playback
+func wait(t *testing.T, done <-chan playbackDone) playbackDone {
+	select {
+	case msg := <-done:
+		return msg
+	case <-time.After(time.Second):
+		t.Fatal("the playback never ended")
+		return playbackDone{}
+	}
+}
+
+func TestPlayback(t *testing.T) {
+	m, speech, out := newTestModel(t)
+
+	done := press(m, "p")
+	if playing, _ := m.player.Playing(); !playing || out.plays != 1 || speech.calls != 1 {
+		t.Fatalf("expected the translation to be played, %d clips played", out.plays)
+	}
+
+	press(m, "x")
+	if playing, _ := m.player.Playing(); playing {
+		t.Error("expected the playback stopped")
+	}
+	if msg := wait(t, done); msg.id != 1 {
+		t.Errorf("expected the end of the first clip, got the end of clip %d", msg.id)
+	}
+
+	done = press(m, "r")
+	if playing, _ := m.player.Playing(); !playing || out.plays != 2 || speech.calls != 1 {
+		t.Fatalf("expected the clip replayed without fetching it again, %d clips played", out.plays)
+	}
+	out.finish()
+	if msg := wait(t, done); msg.id != 2 {
+		t.Errorf("expected the end of the replayed clip, got the end of clip %d", msg.id)
+	}
+	if playing, _ := m.player.Playing(); playing {
+		t.Error("expected the playback over once the clip ended")
+	}
+}
+
+func TestReplayWithoutAudio(t *testing.T) {
+	m, _, out := newTestModel(t)
+
+	press(m, "r")
+	if playing, _ := m.player.Playing(); playing || out.plays != 0 || m.status != "" {
+		t.Errorf("expected nothing to replay, got %d clips played and status %q", out.plays, m.status)
+	}
+}
+
+func TestSlowPlayback(t *testing.T) {
+	m, _, _ := newTestModel(t)
+
+	press(m, "v")
+	if _, slow := m.player.Playing(); !slow {
+		t.Error("expected slowed down playback")
+	}
+	press(m, "v")
+	if _, slow := m.player.Playing(); slow {
+		t.Error("expected normal playback")
+	}
+}
@@ -0,0 +1,80 @@
+package phrasebook
+
+import (
+	"encoding/csv"
+	"html"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ankiColumns are the fields of the exported notes, map them to the fields of
+// the note type when importing
+var ankiColumns = []string{"Front", "Back", "Pronunciation", "Notes", "Audio", "Tags"}
+
+// ExportAnki writes entries as a CSV file Anki can import, separated by sep
+// (',' or '\t'). The headers tell Anki the separator, that fields are html and
+// which column holds the tags. sound returns the name of the audio file of an
+// entry, added to the note as [sound:name], or "" if there is none
+func ExportAnki(w io.Writer, entries []Entry, sep rune, sound func(Entry) string) error {
+	separator := "comma"
+	if sep == '\t' {
+		separator = "tab"
+	}
+	header := "#separator:" + separator + "\n#html:true\n#tags column:" + strconv.Itoa(len(ankiColumns)) + "\n"
+	if _, err := io.WriteString(w, header); err != nil {
+		return err
+	}
+
+	writer := csv.NewWriter(w)
+	writer.Comma = sep
+	columns := append([]string{}, ankiColumns...)
+	columns[0] = "#columns:" + columns[0]
+	writer.Write(columns)
+
+	for _, e := range entries {
+		audio := ""
+		if sound != nil {
+			if name := sound(e); name != "" {
+				audio = "[sound:" + name + "]"
+			}
+		}
+		writer.Write([]string{
+			html.EscapeString(e.Query),
+			html.EscapeString(e.Result.Translation),
+			html.EscapeString(e.Result.Pronunciation),
+			ankiNotes(e),
+			audio,
+			"got " + e.Source + "-" + e.Target,
+		})
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// ankiNotes returns the alternatives, definitions and examples of e as html
+func ankiNotes(e Entry) string {
+	lines := []string{}
+	if len(e.Result.Alternatives) > 0 {
+		lines = append(lines, "<b>Alternatives:</b> "+html.EscapeString(strings.Join(e.Result.Alternatives, ", ")))
+	}
+	for _, group := range e.Result.Definitions {
+		for _, def := range group.Definitions {
+			if def.Definition == "" {
+				continue
+			}
+			line := html.EscapeString(def.Definition)
+			if group.PartOfSpeech != "" {
+				line = "<b>" + html.EscapeString(group.PartOfSpeech) + ":</b> " + line
+			}
+			if def.Example != "" {
+				line += "<br><i>" + html.EscapeString(def.Example) + "</i>"
+			}
+			lines = append(lines, line)
+		}
+	}
+	for _, example := range e.Result.Examples {
+		lines = append(lines, "<i>"+html.EscapeString(example)+"</i>")
+	}
+	return strings.Join(lines, "<br>")
+}
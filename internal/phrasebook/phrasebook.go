@@ -0,0 +1,148 @@
+// Package phrasebook keeps the translations starred in the tui to learn them
+// later, stored as json lines under the user data directory
+package phrasebook
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fedeztk/got/pkg/translator/utils"
+)
+
+// Entry is a translation saved in the phrasebook
+type Entry struct {
+	Query   string       `json:"query"`
+	Source  string       `json:"source"` // the detected language when it was detected
+	Target  string       `json:"target"`
+	Backend string       `json:"backend"`
+	Result  utils.Result `json:"result"`
+	Time    time.Time    `json:"time"`
+}
+
+// Same reports whether e and other are the translation of the same text
+// between the same languages
+func (e Entry) Same(other Entry) bool {
+	return strings.EqualFold(e.Query, other.Query) && e.Source == other.Source && e.Target == other.Target
+}
+
+type Store struct {
+	path string
+}
+
+// NewStore returns the phrasebook stored at path, or under $XDG_DATA_HOME/got,
+// defaulting to ~/.local/share/got, when path is empty
+func NewStore(path string) *Store {
+	if path == "" {
+		dataDir := os.Getenv("XDG_DATA_HOME")
+		if dataDir == "" {
+			home, _ := os.UserHomeDir()
+			dataDir = filepath.Join(home, ".local", "share")
+		}
+		path = filepath.Join(dataDir, "got", "phrasebook.jsonl")
+	}
+	return &Store{path: path}
+}
+
+// Path returns the file the phrasebook is stored in
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Add saves e in the phrasebook, replacing the entry of the same translation if any
+func (s *Store) Add(e Entry) error {
+	entries, err := s.load()
+	if err != nil {
+		return err
+	}
+	return s.save(append(without(entries, e), e))
+}
+
+// Remove deletes the entry of the same translation as e, if any
+func (s *Store) Remove(e Entry) error {
+	entries, err := s.load()
+	if err != nil {
+		return err
+	}
+	return s.save(without(entries, e))
+}
+
+// Entries returns every entry, newest first. Malformed lines are skipped
+func (s *Store) Entries() ([]Entry, error) {
+	entries, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+		entries[i], entries[j] = entries[j], entries[i]
+	}
+	return entries, nil
+}
+
+// load returns the entries in the order they were added
+func (s *Store) load() ([]Entry, error) {
+	f, err := os.Open(s.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	entries := []Entry{}
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024) // full results can be long
+	for scanner.Scan() {
+		var e Entry
+		if json.Unmarshal(scanner.Bytes(), &e) != nil {
+			continue
+		}
+		entries = append(entries, e)
+	}
+	return entries, scanner.Err()
+}
+
+// save replaces the phrasebook with entries, going through a temporary file so
+// that a failure never leaves it half written
+func (s *Store) save(entries []Entry) error {
+	if err := os.MkdirAll(filepath.Dir(s.path), os.ModePerm); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), ".phrasebook-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	w := bufio.NewWriter(tmp)
+	for _, e := range entries {
+		line, err := json.Marshal(e)
+		if err != nil {
+			tmp.Close()
+			return err
+		}
+		w.Write(append(line, '\n'))
+	}
+	if err := w.Flush(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
+
+func without(entries []Entry, e Entry) []Entry {
+	kept := []Entry{}
+	for _, other := range entries {
+		if !other.Same(e) {
+			kept = append(kept, other)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,87 @@
+package phrasebook
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fedeztk/got/pkg/translator/utils"
+)
+
+func TestStore(t *testing.T) {
+	s := NewStore(filepath.Join(t.TempDir(), "got", "phrasebook.jsonl"))
+
+	entries, err := s.Entries()
+	if err != nil || len(entries) != 0 {
+		t.Fatalf("got %v, %v, want an empty phrasebook", entries, err)
+	}
+
+	ciao := Entry{Query: "ciao", Source: "it", Target: "en", Result: utils.Result{Translation: "hello"}}
+	sera := Entry{Query: "buonasera", Source: "it", Target: "en", Result: utils.Result{Translation: "good evening"}}
+	for _, e := range []Entry{ciao, sera} {
+		if err := s.Add(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// saving the same translation again replaces it and moves it on top
+	ciao.Query, ciao.Result.Translation = "Ciao", "hi"
+	if err := s.Add(ciao); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err = s.Entries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 || entries[0].Result.Translation != "hi" || entries[1].Query != "buonasera" {
+		t.Fatalf("got %v, want hi then buonasera", entries)
+	}
+
+	if err := s.Remove(Entry{Query: "ciao", Source: "it", Target: "en"}); err != nil {
+		t.Fatal(err)
+	}
+	if entries, _ = s.Entries(); len(entries) != 1 || entries[0].Query != "buonasera" {
+		t.Errorf("got %v, want only buonasera", entries)
+	}
+}
+
+func TestExportAnki(t *testing.T) {
+	entries := []Entry{
+		{
+			Query:  "cane",
+			Source: "it",
+			Target: "en",
+			Result: utils.Result{
+				Translation:   "dog",
+				Pronunciation: "dɔg",
+				Alternatives:  []string{"hound"},
+				Definitions: []utils.DefinitionGroup{{
+					PartOfSpeech: "noun",
+					Definitions:  []utils.Definition{{Definition: "a <domesticated> animal", Example: "the dog barked"}},
+				}},
+			},
+		},
+		{Query: "gatto, micio", Source: "it", Target: "en", Result: utils.Result{Translation: "cat"}},
+	}
+	sound := func(e Entry) string {
+		if e.Query == "cane" {
+			return "en-dog.mp3"
+		}
+		return ""
+	}
+
+	builder := strings.Builder{}
+	if err := ExportAnki(&builder, entries, ',', sound); err != nil {
+		t.Fatal(err)
+	}
+	want := `#separator:comma
+#html:true
+#tags column:6
+#columns:Front,Back,Pronunciation,Notes,Audio,Tags
+cane,dog,dɔg,<b>Alternatives:</b> hound<br><b>noun:</b> a &lt;domesticated&gt; animal<br><i>the dog barked</i>,[sound:en-dog.mp3],got it-en
+"gatto, micio",cat,,,,got it-en
+`
+	if got := builder.String(); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,104 @@
+package po
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// unquote returns the value of a C string literal as found in catalogs
+func unquote(s string) (string, error) {
+	if len(s) < 2 || s[0] != '"' || s[len(s)-1] != '"' {
+		return "", errors.New("string not quoted")
+	}
+	s = s[1 : len(s)-1]
+
+	builder := strings.Builder{}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c == '"' {
+			return "", errors.New("unescaped quote in string")
+		}
+		if c != '\\' {
+			builder.WriteByte(c)
+			continue
+		}
+		i++
+		if i == len(s) {
+			return "", errors.New("string ends with a backslash")
+		}
+		switch c = s[i]; c {
+		case 'n':
+			builder.WriteByte('\n')
+		case 't':
+			builder.WriteByte('\t')
+		case 'r':
+			builder.WriteByte('\r')
+		case 'a':
+			builder.WriteByte('\a')
+		case 'b':
+			builder.WriteByte('\b')
+		case 'f':
+			builder.WriteByte('\f')
+		case 'v':
+			builder.WriteByte('\v')
+		case '\\', '"', '\'', '?':
+			builder.WriteByte(c)
+		case 'x':
+			end := i + 1
+			for end < len(s) && end < i+3 && isHex(s[end]) {
+				end++
+			}
+			if end == i+1 {
+				return "", errors.New("invalid hexadecimal escape")
+			}
+			n, _ := strconv.ParseUint(s[i+1:end], 16, 8)
+			builder.WriteByte(byte(n))
+			i = end - 1
+		case '0', '1', '2', '3', '4', '5', '6', '7':
+			end := i
+			for end < len(s) && end < i+3 && s[end] >= '0' && s[end] <= '7' {
+				end++
+			}
+			n, _ := strconv.ParseUint(s[i:end], 8, 8)
+			builder.WriteByte(byte(n))
+			i = end - 1
+		default:
+			return "", errors.New(`unknown escape sequence \` + string(c))
+		}
+	}
+	return builder.String(), nil
+}
+
+func isHex(c byte) bool {
+	return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
+
+// quote returns s as a C string literal, escaping quotes, backslashes and
+// control characters
+func quote(s string) string {
+	builder := strings.Builder{}
+	builder.WriteByte('"')
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '"', '\\':
+			builder.WriteByte('\\')
+			builder.WriteByte(c)
+		case '\n':
+			builder.WriteString(`\n`)
+		case '\t':
+			builder.WriteString(`\t`)
+		case '\r':
+			builder.WriteString(`\r`)
+		default:
+			if c < 0x20 || c == 0x7f {
+				builder.WriteString(fmt.Sprintf(`\%03o`, c))
+			} else {
+				builder.WriteByte(c)
+			}
+		}
+	}
+	builder.WriteByte('"')
+	return builder.String()
+}
@@ -0,0 +1,336 @@
+// Package po reads and writes gettext catalogs (.po and .pot files). A catalog
+// keeps the lines it was read from, so that writing it back only changes the
+// entries that were modified
+package po
+
+import (
+	"errors"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// File is a gettext catalog
+type File struct {
+	Entries []*Entry
+}
+
+// Entry is a message of the catalog with the comments before it, obsolete
+// entries (#~) are kept as comments
+type Entry struct {
+	Comments []string // raw comment lines
+	fields   []*field
+	trailing []string // raw blank lines after the entry
+}
+
+// field is a keyword of an entry (msgctxt, msgid, msgid_plural, msgstr or
+// msgstr[n]) with its unescaped value and the raw lines it was read from,
+// nil for the modified ones
+type field struct {
+	keyword string
+	index   int // n of msgstr[n], -1 for the other keywords
+	value   string
+	raw     []string
+}
+
+var (
+	keywordLine = regexp.MustCompile(`^(msgctxt|msgid_plural|msgid|msgstr)(?:\[(\d+)\])?\s+(".*")\s*$`)
+	nplurals    = regexp.MustCompile(`nplurals\s*=\s*(\d+)`)
+)
+
+// Parse reads a catalog
+func Parse(r io.Reader) (*File, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	lines := strings.Split(string(data), "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	f := &File{}
+	e := &Entry{}
+	flush := func() {
+		if len(e.Comments) > 0 || len(e.fields) > 0 || len(e.trailing) > 0 {
+			f.Entries = append(f.Entries, e)
+		}
+		e = &Entry{}
+	}
+
+	var last *field // the field continuation strings are appended to
+	for n, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "":
+			e.trailing = append(e.trailing, line)
+			last = nil
+
+		case strings.HasPrefix(trimmed, "#"):
+			if len(e.fields) > 0 || len(e.trailing) > 0 {
+				flush()
+			}
+			e.Comments = append(e.Comments, line)
+			last = nil
+
+		case strings.HasPrefix(trimmed, `"`):
+			if last == nil {
+				return nil, lineError(n, "string without a keyword")
+			}
+			value, err := unquote(trimmed)
+			if err != nil {
+				return nil, lineError(n, err.Error())
+			}
+			last.value += value
+			last.raw = append(last.raw, line)
+
+		default:
+			match := keywordLine.FindStringSubmatch(trimmed)
+			if match == nil {
+				return nil, lineError(n, "unknown keyword")
+			}
+			keyword := match[1]
+			// a message starts with its context, or its id when there is none
+			if (keyword == "msgctxt" && len(e.fields) > 0) || (keyword == "msgid" && e.has("msgid")) ||
+				((keyword == "msgctxt" || keyword == "msgid") && len(e.trailing) > 0) {
+				flush()
+			}
+			value, err := unquote(match[3])
+			if err != nil {
+				return nil, lineError(n, err.Error())
+			}
+			index := -1
+			if match[2] != "" {
+				index, _ = strconv.Atoi(match[2])
+			}
+			last = &field{keyword: keyword, index: index, value: value, raw: []string{line}}
+			e.fields = append(e.fields, last)
+		}
+	}
+	flush()
+	return f, nil
+}
+
+func lineError(n int, msg string) error {
+	return errors.New("line " + strconv.Itoa(n+1) + ": " + msg)
+}
+
+// Write writes the catalog, the entries that were not modified exactly as they were read
+func (f *File) Write(w io.Writer) error {
+	builder := strings.Builder{}
+	for _, e := range f.Entries {
+		for _, line := range e.Comments {
+			builder.WriteString(line + "\n")
+		}
+		for _, fl := range e.fields {
+			lines := fl.raw
+			if lines == nil {
+				lines = formatField(fl)
+			}
+			for _, line := range lines {
+				builder.WriteString(line + "\n")
+			}
+		}
+		for _, line := range e.trailing {
+			builder.WriteString(line + "\n")
+		}
+	}
+	_, err := io.WriteString(w, builder.String())
+	return err
+}
+
+// formatField returns the lines of fl, multiline values are split after
+// every newline as gettext does
+func formatField(fl *field) []string {
+	name := fl.keyword
+	if fl.index >= 0 {
+		name += "[" + strconv.Itoa(fl.index) + "]"
+	}
+	parts := strings.SplitAfter(fl.value, "\n")
+	if parts[len(parts)-1] == "" {
+		parts = parts[:len(parts)-1]
+	}
+	if len(parts) <= 1 {
+		return []string{name + " " + quote(fl.value)}
+	}
+	lines := []string{name + ` ""`}
+	for _, p := range parts {
+		lines = append(lines, quote(p))
+	}
+	return lines
+}
+
+// Header returns the header entry, the one with an empty msgid, or nil
+func (f *File) Header() *Entry {
+	for _, e := range f.Entries {
+		if e.IsHeader() {
+			return e
+		}
+	}
+	return nil
+}
+
+// HeaderField returns the value of a field of the header like Language, empty if it is not set
+func (f *File) HeaderField(name string) string {
+	h := f.Header()
+	if h == nil {
+		return ""
+	}
+	for _, line := range strings.Split(h.get("msgstr", -1), "\n") {
+		if key, value, ok := cut(line, ":"); ok && strings.EqualFold(strings.TrimSpace(key), name) {
+			return strings.TrimSpace(value)
+		}
+	}
+	return ""
+}
+
+// SetHeaderField sets a field of the header, adding it if it is missing
+func (f *File) SetHeaderField(name, value string) {
+	h := f.Header()
+	if h == nil {
+		return
+	}
+	lines := strings.SplitAfter(h.get("msgstr", -1), "\n")
+	for i, line := range lines {
+		if key, _, ok := cut(line, ":"); ok && strings.EqualFold(strings.TrimSpace(key), name) {
+			lines[i] = name + ": " + value + "\n"
+			h.set("msgstr", -1, strings.Join(lines, ""))
+			return
+		}
+	}
+	h.set("msgstr", -1, strings.Join(lines, "")+name+": "+value+"\n")
+}
+
+// NPlurals returns the number of plural forms of the language of the catalog
+// read from the Plural-Forms header, 0 if it is not set
+func (f *File) NPlurals() int {
+	match := nplurals.FindStringSubmatch(f.HeaderField("Plural-Forms"))
+	if match == nil {
+		return 0
+	}
+	n, _ := strconv.Atoi(match[1])
+	return n
+}
+
+func cut(s, sep string) (before, after string, found bool) {
+	if i := strings.Index(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+	return s, "", false
+}
+
+// Context returns the msgctxt of e
+func (e *Entry) Context() string {
+	return e.get("msgctxt", -1)
+}
+
+// ID returns the msgid of e
+func (e *Entry) ID() string {
+	return e.get("msgid", -1)
+}
+
+// Plural returns the msgid_plural of e, empty if it has no plural forms
+func (e *Entry) Plural() string {
+	return e.get("msgid_plural", -1)
+}
+
+// IsHeader reports whether e is the header of the catalog
+func (e *Entry) IsHeader() bool {
+	return e.has("msgid") && e.ID() == "" && !e.has("msgctxt")
+}
+
+// IsTranslated reports whether e is a message with a translation, the header and
+// obsolete entries are considered translated
+func (e *Entry) IsTranslated() bool {
+	if !e.has("msgid") || e.IsHeader() {
+		return true
+	}
+	for _, fl := range e.fields {
+		if fl.keyword == "msgstr" && fl.value != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// Translate sets the translation of e: singular is the one of the msgid and
+// plural the one of msgid_plural, used for the n plural forms of an entry with
+// plurals. At least the plural forms already in e are set
+func (e *Entry) Translate(singular, plural string, n int) {
+	if !e.has("msgid_plural") {
+		e.set("msgstr", -1, singular)
+		return
+	}
+	for i := 0; i < n || e.has("msgstr", i); i++ {
+		if i == 0 {
+			e.set("msgstr", i, singular)
+		} else {
+			e.set("msgstr", i, plural)
+		}
+	}
+}
+
+// AddFlag adds a flag like fuzzy in front of the #, comment of e, creating it
+// if needed before the comments with the previous message (#|)
+func (e *Entry) AddFlag(flag string) {
+	insert := len(e.Comments)
+	for i, line := range e.Comments {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "#,") {
+			for _, f := range strings.Split(trimmed[2:], ",") {
+				if strings.TrimSpace(f) == flag {
+					return
+				}
+			}
+			e.Comments[i] = "#, " + flag + "," + strings.TrimRight(trimmed[2:], " \r")
+			return
+		}
+		if (strings.HasPrefix(trimmed, "#|") || strings.HasPrefix(trimmed, "#~")) && insert == len(e.Comments) {
+			insert = i
+		}
+	}
+	comments := append([]string{}, e.Comments[:insert]...)
+	comments = append(comments, "#, "+flag)
+	e.Comments = append(comments, e.Comments[insert:]...)
+}
+
+// has reports whether e has the keyword, with the given index for msgstr[n]
+func (e *Entry) has(keyword string, index ...int) bool {
+	return e.find(keyword, index...) != nil
+}
+
+func (e *Entry) find(keyword string, index ...int) *field {
+	for _, fl := range e.fields {
+		if fl.keyword == keyword && (len(index) == 0 || fl.index == index[0]) {
+			return fl
+		}
+	}
+	return nil
+}
+
+func (e *Entry) get(keyword string, index int) string {
+	if fl := e.find(keyword, index); fl != nil {
+		return fl.value
+	}
+	return ""
+}
+
+// set changes the value of a field, adding it after the last msgstr if missing
+func (e *Entry) set(keyword string, index int, value string) {
+	if fl := e.find(keyword, index); fl != nil {
+		if fl.value != value {
+			fl.value, fl.raw = value, nil
+		}
+		return
+	}
+	insert := len(e.fields)
+	for i, fl := range e.fields {
+		if fl.keyword == keyword {
+			insert = i + 1
+		}
+	}
+	fields := append([]*field{}, e.fields[:insert]...)
+	fields = append(fields, &field{keyword: keyword, index: index, value: value})
+	e.fields = append(fields, e.fields[insert:]...)
+}
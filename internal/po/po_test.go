@@ -0,0 +1,177 @@
+package po
+
+import (
+	"strings"
+	"testing"
+)
+
+const catalog = `# Translator comment
+#, fuzzy
+msgid ""
+msgstr ""
+"Language: \n"
+"Plural-Forms: nplurals=3; plural=(n==1 ? 0 : n%10>=2 && n%10<=4 && (n%100<10 || n%100>=20) ? 1 : 2);\n"
+
+#: main.c:10
+#, c-format
+msgid "Hello %s\n"
+msgstr ""
+
+msgctxt "menu"
+msgid   "Open"
+msgstr  "Otwórz"
+
+#: main.c:20
+#| msgid "a file"
+msgid "%d file"
+msgid_plural "%d files"
+msgstr[0] ""
+msgstr[1] ""
+
+msgid ""
+"Say \"hi\"\n"
+"to\t{name}\101"
+msgstr ""
+#~ msgid "Old"
+#~ msgstr "Stary"
+`
+
+func parse(t *testing.T, text string) *File {
+	t.Helper()
+	f, err := Parse(strings.NewReader(text))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+func write(t *testing.T, f *File) string {
+	t.Helper()
+	builder := strings.Builder{}
+	if err := f.Write(&builder); err != nil {
+		t.Fatal(err)
+	}
+	return builder.String()
+}
+
+func TestRoundTrip(t *testing.T) {
+	if got := write(t, parse(t, catalog)); got != catalog {
+		t.Errorf("got\n%s\nwant\n%s", got, catalog)
+	}
+}
+
+func TestEntries(t *testing.T) {
+	f := parse(t, catalog)
+	if len(f.Entries) != 6 {
+		t.Fatalf("got %d entries, want 6", len(f.Entries))
+	}
+	if !f.Entries[0].IsHeader() || f.NPlurals() != 3 || f.HeaderField("Language") != "" {
+		t.Errorf("unexpected header %+v", f.Entries[0])
+	}
+
+	untranslated := []string{}
+	for _, e := range f.Entries {
+		if !e.IsTranslated() {
+			untranslated = append(untranslated, e.ID())
+		}
+	}
+	want := []string{"Hello %s\n", "%d file", "Say \"hi\"\nto\t{name}A"}
+	if strings.Join(untranslated, "|") != strings.Join(want, "|") {
+		t.Errorf("got untranslated %q, want %q", untranslated, want)
+	}
+	if e := f.Entries[2]; e.Context() != "menu" || e.ID() != "Open" {
+		t.Errorf("got context %q and id %q, want menu and Open", e.Context(), e.ID())
+	}
+	if e := f.Entries[3]; e.Plural() != "%d files" {
+		t.Errorf("got plural %q, want %%d files", e.Plural())
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	f := parse(t, catalog)
+	f.Entries[1].Translate("Cześć %s\n", "", f.NPlurals())
+	f.Entries[1].AddFlag("fuzzy")
+	f.Entries[3].Translate("%d plik", "%d pliki", f.NPlurals())
+	f.Entries[3].AddFlag("fuzzy")
+	f.Entries[4].Translate("Powiedz \"cześć\"\ndo\t{name}", "", f.NPlurals())
+	f.Entries[4].AddFlag("fuzzy")
+	f.SetHeaderField("Language", "pl")
+
+	want := `# Translator comment
+#, fuzzy
+msgid ""
+msgstr ""
+"Language: pl\n"
+"Plural-Forms: nplurals=3; plural=(n==1 ? 0 : n%10>=2 && n%10<=4 && (n%100<10 || n%100>=20) ? 1 : 2);\n"
+
+#: main.c:10
+#, fuzzy, c-format
+msgid "Hello %s\n"
+msgstr "Cześć %s\n"
+
+msgctxt "menu"
+msgid   "Open"
+msgstr  "Otwórz"
+
+#: main.c:20
+#, fuzzy
+#| msgid "a file"
+msgid "%d file"
+msgid_plural "%d files"
+msgstr[0] "%d plik"
+msgstr[1] "%d pliki"
+msgstr[2] "%d pliki"
+
+#, fuzzy
+msgid ""
+"Say \"hi\"\n"
+"to\t{name}\101"
+msgstr ""
+"Powiedz \"cześć\"\n"
+"do\t{name}"
+#~ msgid "Old"
+#~ msgstr "Stary"
+`
+	if got := write(t, f); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestContextThenPlain(t *testing.T) {
+	text := `msgctxt "menu"
+msgid "Open"
+msgstr ""
+msgid "Close"
+msgstr ""
+
+msgid "Save"
+msgstr ""
+`
+	f := parse(t, text)
+	ids := []string{}
+	for _, e := range f.Entries {
+		ids = append(ids, e.Context()+"|"+e.ID())
+	}
+	if want := "menu|Open,|Close,|Save"; strings.Join(ids, ",") != want {
+		t.Fatalf("got entries %q, want %s", ids, want)
+	}
+
+	f.Entries[1].Translate("Chiudi", "", 0)
+	want := strings.Replace(text, "msgid \"Close\"\nmsgstr \"\"", "msgid \"Close\"\nmsgstr \"Chiudi\"", 1)
+	if got := write(t, f); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, text := range []string{
+		"\"dangling string\"\n",
+		"msgid \"unterminated\n",
+		"msgid \"bad \\q escape\"\n",
+		"msgfoo \"unknown\"\n",
+	} {
+		if _, err := Parse(strings.NewReader(text)); err == nil {
+			t.Errorf("expected an error parsing %q", text)
+		}
+	}
+}
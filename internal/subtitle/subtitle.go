@@ -0,0 +1,139 @@
+// Package subtitle reads and writes SubRip (.srt) and WebVTT (.vtt) subtitles.
+// Only the text of the cues can be changed, everything else is written back as
+// it was read
+package subtitle
+
+import (
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// File is a subtitles file
+type File struct {
+	VTT    bool
+	Blocks []*Block
+	crlf   bool
+	bom    bool
+}
+
+// Block is a group of lines separated from the others by a blank line: a cue
+// or, in WebVTT files, the header, a comment (NOTE), a STYLE or a REGION
+type Block struct {
+	ID     string   // index of a SubRip cue or identifier of a WebVTT one, may be empty
+	Timing string   // line with the timestamps and the settings, empty for the blocks that are not cues
+	Text   []string // lines of text of a cue, all the lines of the other blocks
+}
+
+// IsCue reports whether b is a cue
+func (b *Block) IsCue() bool {
+	return b.Timing != ""
+}
+
+// Parse reads a subtitles file, WebVTT if it starts with WEBVTT and SubRip otherwise
+func Parse(r io.Reader) (*File, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	text := string(data)
+	f := &File{}
+	if strings.HasPrefix(text, "\ufeff") {
+		f.bom = true
+		text = text[len("\ufeff"):]
+	}
+	if strings.Contains(text, "\r\n") {
+		f.crlf = true
+		text = strings.ReplaceAll(text, "\r\n", "\n")
+	}
+	f.VTT = strings.HasPrefix(text, "WEBVTT")
+
+	lines := []string{}
+	flush := func() {
+		if len(lines) > 0 {
+			f.Blocks = append(f.Blocks, newBlock(lines, f.VTT && len(f.Blocks) == 0))
+			lines = []string{}
+		}
+	}
+	for _, line := range strings.Split(text, "\n") {
+		if strings.TrimSpace(line) == "" {
+			flush()
+		} else {
+			lines = append(lines, line)
+		}
+	}
+	flush()
+
+	if !f.VTT {
+		for i, b := range f.Blocks {
+			if !b.IsCue() {
+				return nil, errors.New("block " + strconv.Itoa(i+1) + " is not a cue, its timestamps are missing")
+			}
+		}
+	}
+	return f, nil
+}
+
+// newBlock returns the block made of lines, the timing of a cue being on its
+// first or second line
+func newBlock(lines []string, header bool) *Block {
+	if !header {
+		for _, keyword := range []string{"NOTE", "STYLE", "REGION"} {
+			if lines[0] == keyword || strings.HasPrefix(lines[0], keyword+" ") || strings.HasPrefix(lines[0], keyword+"\t") {
+				header = true
+			}
+		}
+	}
+	if !header {
+		for i := 0; i < len(lines) && i < 2; i++ {
+			if strings.Contains(lines[i], "-->") {
+				b := &Block{Timing: lines[i], Text: lines[i+1:]}
+				if i == 1 {
+					b.ID = lines[0]
+				}
+				return b
+			}
+		}
+	}
+	return &Block{Text: lines}
+}
+
+// Cues returns the cues of f, in order
+func (f *File) Cues() []*Block {
+	cues := []*Block{}
+	for _, b := range f.Blocks {
+		if b.IsCue() {
+			cues = append(cues, b)
+		}
+	}
+	return cues
+}
+
+// Write writes f with its original line endings
+func (f *File) Write(w io.Writer) error {
+	builder := strings.Builder{}
+	if f.bom {
+		builder.WriteString("\ufeff")
+	}
+	for i, b := range f.Blocks {
+		if i > 0 {
+			builder.WriteString("\n")
+		}
+		if b.ID != "" {
+			builder.WriteString(b.ID + "\n")
+		}
+		if b.Timing != "" {
+			builder.WriteString(b.Timing + "\n")
+		}
+		for _, line := range b.Text {
+			builder.WriteString(line + "\n")
+		}
+	}
+	text := builder.String()
+	if f.crlf {
+		text = strings.ReplaceAll(text, "\n", "\r\n")
+	}
+	_, err := io.WriteString(w, text)
+	return err
+}
@@ -0,0 +1,107 @@
+package subtitle
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/fedeztk/got/internal/translatortest"
+)
+
+const srt = "\ufeff1\r\n00:00:01,000 --> 00:00:02,500\r\n<i>Hello there,</i>\r\nhow are you?\r\n\r\n" +
+	"2\r\n00:00:03,000 --> 00:00:04,000\r\n{\\an8}Fine &amp; you?\r\n"
+
+const vtt = `WEBVTT - training
+
+NOTE a comment
+on two lines
+
+STYLE
+::cue { color: yellow }
+
+intro
+00:00.000 --> 00:02.000 align:start
+<v Roger>Welcome to <c.yellow>the</c> course</v>
+
+00:02.500 --> 00:04.000
+- First line
+- Second line
+`
+
+func parse(t *testing.T, text string) *File {
+	t.Helper()
+	f, err := Parse(strings.NewReader(text))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+func write(t *testing.T, f *File) string {
+	t.Helper()
+	builder := strings.Builder{}
+	if err := f.Write(&builder); err != nil {
+		t.Fatal(err)
+	}
+	return builder.String()
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, text := range []string{srt, vtt} {
+		if got := write(t, parse(t, text)); got != text {
+			t.Errorf("got\n%q\nwant\n%q", got, text)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	f := parse(t, vtt)
+	if !f.VTT || len(f.Blocks) != 5 {
+		t.Fatalf("got %d blocks, want the 5 of a WebVTT file", len(f.Blocks))
+	}
+	cues := f.Cues()
+	if len(cues) != 2 || cues[0].ID != "intro" || cues[1].ID != "" || len(cues[1].Text) != 2 {
+		t.Errorf("unexpected cues %+v %+v", cues[0], cues[1])
+	}
+	if _, err := Parse(strings.NewReader("1\nno timestamps\n")); err == nil {
+		t.Error("expected an error parsing a SubRip block without timestamps")
+	}
+}
+
+var placeholderLine = regexp.MustCompile(`\n\[\[\d+\]\]\n`)
+
+func TestTranslate(t *testing.T) {
+	for _, lose := range []bool{false, true} {
+		f := parse(t, srt+"\r\n3\r\n00:00:05,000 --> 00:00:06,000\r\n- Yes\r\n- No\r\n")
+		b := &translatortest.Upper{}
+		if lose { // the lines holding only a placeholder, the separators of the cues, are lost
+			b.Edit = func(text string) string { return placeholderLine.ReplaceAllString(text, " ") }
+		}
+		for _, err := range f.Translate(context.Background(), b, "en", "it", "google") {
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		want := "\ufeff1\r\n00:00:01,000 --> 00:00:02,500\r\n<i>HELLO THERE,</i>\r\nHOW ARE YOU?\r\n\r\n" +
+			"2\r\n00:00:03,000 --> 00:00:04,000\r\n{\\an8}FINE &amp; YOU?\r\n\r\n" +
+			"3\r\n00:00:05,000 --> 00:00:06,000\r\n- YES\r\n- NO\r\n"
+		if got := write(t, f); got != want {
+			t.Errorf("got\n%q\nwant\n%q", got, want)
+		}
+		// a single batch, then every cue on its own once the separators are lost
+		requests := 1
+		if lose {
+			requests = 4
+		}
+		if len(b.Texts()) != requests {
+			t.Errorf("got %d requests, want %d", len(b.Texts()), requests)
+		}
+		for _, text := range b.Texts() {
+			if strings.ContainsAny(text, "<>{}&") {
+				t.Errorf("tags reached the backend: %q", text)
+			}
+		}
+	}
+}
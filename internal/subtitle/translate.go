@@ -0,0 +1,91 @@
+package subtitle
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/fedeztk/got/pkg/translator"
+)
+
+// Tags matches the styling of the cues, which must reach the translation
+// unchanged: html like tags (<i>, <font color="red">, the <v Speaker>, <c.class>
+// and <00:01.000> of WebVTT), SubStation overrides like {\an8} and entities like &amp;
+var Tags = regexp.MustCompile(`<[^<>]*>|\{\\[^{}]*\}|&(?:#\d+|#x[0-9a-fA-F]+|[a-zA-Z]+);`)
+
+// separator stands for the boundary between the cues of a batch
+const separator = "\x00cue"
+
+// batch is a group of consecutive cues translated in a single request
+type batch struct {
+	cues         []int
+	placeholders *translator.Placeholders
+	text         string
+}
+
+// Translate translates the text of the cues of f to target, keeping the tags
+// and the number of lines of every cue. Consecutive cues are sent together in
+// requests of up to translator.MaxChunkLength bytes, the cues of a batch whose
+// boundaries were lost in translation are sent again one by one. The returned
+// errors follow the order of f.Cues(), nil for the translated cues
+func (f *File) Translate(ctx context.Context, b translator.Backend, source, target, engine string) []error {
+	cues := f.Cues()
+	errs := make([]error, len(cues))
+
+	batches := []*batch{}
+	var current *batch
+	for i, cue := range cues {
+		text := strings.TrimSpace(strings.Join(cue.Text, "\n"))
+		if text == "" {
+			continue
+		}
+		if current != nil {
+			masked := current.placeholders.Protect(text, Tags)
+			sep := "\n" + current.placeholders.Add(separator) + "\n"
+			if len(current.text)+len(sep)+len(masked) <= translator.MaxChunkLength {
+				current.text += sep + masked
+				current.cues = append(current.cues, i)
+				continue
+			}
+		}
+		current = &batch{cues: []int{i}, placeholders: &translator.Placeholders{}}
+		current.text = current.placeholders.Protect(text, Tags)
+		batches = append(batches, current)
+	}
+
+	texts := make([]string, len(batches))
+	for i, bt := range batches {
+		texts[i] = bt.text
+	}
+	retry := []int{}
+	for i, r := range translator.TranslateTexts(ctx, b, texts, source, target, engine, translator.MaxWorkers, nil) {
+		bt := batches[i]
+		if r.Err != nil {
+			for _, c := range bt.cues {
+				errs[c] = r.Err
+			}
+			continue
+		}
+		parts := bt.placeholders.Split(r.Text, separator)
+		if len(parts) != len(bt.cues) {
+			retry = append(retry, bt.cues...)
+			continue
+		}
+		for j, c := range bt.cues {
+			cues[c].Text = translator.Rewrap(bt.placeholders.Restore(parts[j]), len(cues[c].Text))
+		}
+	}
+
+	texts = make([]string, len(retry))
+	for i, c := range retry {
+		texts[i] = strings.Join(cues[c].Text, "\n")
+	}
+	for i, r := range translator.TranslateTexts(ctx, b, texts, source, target, engine, translator.MaxWorkers, Tags) {
+		if r.Err != nil {
+			errs[retry[i]] = r.Err
+			continue
+		}
+		cues[retry[i]].Text = translator.Rewrap(r.Text, len(cues[retry[i]].Text))
+	}
+	return errs
+}
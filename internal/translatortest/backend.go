@@ -0,0 +1,39 @@
+// Package translatortest provides a fake backend for the tests of the
+// packages translating through a translator.Backend
+package translatortest
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/fedeztk/got/pkg/translator"
+	"github.com/fedeztk/got/pkg/translator/utils"
+)
+
+// Upper is a backend translating by upper casing the texts, it records the
+// texts it receives. The other methods are not implemented
+type Upper struct {
+	translator.Backend
+	Edit func(text string) string // if set, changes the texts before they are upper cased
+
+	mu    sync.Mutex
+	texts []string
+}
+
+func (b *Upper) Translate(ctx context.Context, text, source, target, engine string) (utils.BackendResponse, error) {
+	b.mu.Lock()
+	b.texts = append(b.texts, text)
+	b.mu.Unlock()
+	if b.Edit != nil {
+		text = b.Edit(text)
+	}
+	return utils.Result{Translation: strings.ToUpper(text)}, nil
+}
+
+// Texts returns the texts received so far
+func (b *Upper) Texts() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]string{}, b.texts...)
+}
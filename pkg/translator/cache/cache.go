@@ -0,0 +1,258 @@
+// Package cache provides a translator.Backend that keeps the translations and
+// the text to speech audio on disk, so that repeated lookups are served
+// without hitting the network
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fedeztk/got/pkg/translator"
+	"github.com/fedeztk/got/pkg/translator/utils"
+)
+
+type Options struct {
+	TTL          time.Duration // how long a translation is served from the cache
+	MaxSize      int64         // size in bytes above which the oldest translations are evicted
+	AudioMaxSize int64         // size in bytes above which the oldest audio is evicted, audio never expires
+}
+
+// Backend wraps a translator.Backend caching its translations and its text
+// to speech audio, the other methods are passed through
+type Backend struct {
+	translator.Backend
+	name     string
+	dir      string
+	audioDir string
+	opts     Options
+	mu       sync.Mutex       // serializes writes and evictions
+	sizes    map[string]int64 // running size in bytes of the directories, known after their first scan
+}
+
+type entry struct {
+	Backend  string       `json:"backend"`
+	Engine   string       `json:"engine"`
+	Source   string       `json:"source"`
+	Target   string       `json:"target"`
+	Text     string       `json:"text"`
+	Response utils.Result `json:"response"`
+}
+
+type audioEntry struct {
+	Backend  string `json:"backend"`
+	Language string `json:"language"`
+	Text     string `json:"text"`
+	Audio    []byte `json:"audio"`
+}
+
+// hit is a response served from the cache
+type hit struct {
+	utils.BackendResponse
+}
+
+// Hit reports whether r was served from the cache, looking through the
+// responses wrapping it
+func Hit(r utils.BackendResponse) bool {
+	for {
+		if _, ok := r.(hit); ok {
+			return true
+		}
+		wrapper, ok := r.(interface{ Unwrap() utils.BackendResponse })
+		if !ok {
+			return false
+		}
+		r = wrapper.Unwrap()
+	}
+}
+
+// TranslationsDir is where the translations are cached
+func TranslationsDir() string {
+	return utils.CacheDir("translations")
+}
+
+// AudioDir is where the text to speech audio is cached
+func AudioDir() string {
+	return utils.CacheDir("audio")
+}
+
+// New returns b with its translations and audio cached, name is the name of
+// the backend as given to translator.NewBackend
+func New(b translator.Backend, name string, opts Options) *Backend {
+	return &Backend{
+		Backend:  b,
+		name:     name,
+		dir:      TranslationsDir(),
+		audioDir: AudioDir(),
+		opts:     opts,
+		sizes:    map[string]int64{},
+	}
+}
+
+func (b *Backend) Translate(ctx context.Context, text, source, target, engine string) (utils.BackendResponse, error) {
+	e := entry{Backend: b.name, Engine: engine, Source: source, Target: target, Text: text}
+	path := b.path(e)
+
+	if r, ok := b.load(path); ok {
+		return hit{r}, nil
+	}
+
+	r, err := b.Backend.Translate(ctx, text, source, target, engine)
+	if err != nil || text == "" {
+		return r, err
+	}
+
+	// caching is best effort, a failure only means asking the backend again
+	e.Response = r.Result()
+	b.store(path, e)
+	return r, nil
+}
+
+func (b *Backend) TextToSpeech(ctx context.Context, text, language string) ([]byte, error) {
+	e := audioEntry{Backend: b.name, Language: language, Text: text}
+	path := filepath.Join(b.audioDir, key(e.Backend, e.Language, e.Text))
+
+	if audio, ok := loadAudio(path); ok {
+		return audio, nil
+	}
+
+	audio, err := b.Backend.TextToSpeech(ctx, text, language)
+	if err != nil || len(audio) == 0 {
+		return audio, err
+	}
+
+	e.Audio = audio
+	b.write(b.audioDir, path, e, b.opts.AudioMaxSize)
+	return audio, nil
+}
+
+// CachedAudio returns the audio of text spoken in language cached for the
+// backend name, without ever asking the backend
+func CachedAudio(name, language, text string) ([]byte, bool) {
+	return loadAudio(filepath.Join(AudioDir(), key(name, language, text)))
+}
+
+// loadAudio returns the audio cached in the file at path
+func loadAudio(path string) ([]byte, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+
+	var e audioEntry
+	if json.Unmarshal(data, &e) != nil || len(e.Audio) == 0 {
+		return nil, false
+	}
+	return e.Audio, true
+}
+
+func (b *Backend) path(e entry) string {
+	return filepath.Join(b.dir, key(e.Backend, e.Engine, e.Source, e.Target, e.Text))
+}
+
+// key returns the name of the file caching the entry made of fields
+func key(fields ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(fields, "\x00")))
+	return hex.EncodeToString(sum[:]) + ".json"
+}
+
+func (b *Backend) load(path string) (utils.BackendResponse, bool) {
+	info, err := os.Stat(path)
+	if err != nil || time.Since(info.ModTime()) > b.opts.TTL {
+		return nil, false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+
+	var e entry
+	if json.Unmarshal(data, &e) != nil {
+		return nil, false
+	}
+	return e.Response, true
+}
+
+func (b *Backend) store(path string, e entry) {
+	b.write(b.dir, path, e, b.opts.MaxSize)
+}
+
+// write stores v as json at path inside dir, then evicts the oldest files of
+// dir above maxSize. The size of dir is tracked across writes, so that it is
+// scanned only the first time and when it grows above maxSize
+func (b *Backend) write(dir, path string, v interface{}, maxSize int64) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if os.MkdirAll(dir, os.ModePerm) != nil {
+		return
+	}
+	var replaced int64
+	if info, err := os.Stat(path); err == nil {
+		replaced = info.Size()
+	}
+	if os.WriteFile(path, data, 0o644) != nil {
+		delete(b.sizes, dir) // the file may be half written, scan again next time
+		return
+	}
+
+	size, ok := b.sizes[dir]
+	size += int64(len(data)) - replaced
+	if !ok || size > maxSize {
+		if size, err = evict(dir, maxSize); err != nil {
+			delete(b.sizes, dir)
+			return
+		}
+	}
+	b.sizes[dir] = size
+}
+
+// evict removes the oldest files of dir until its size is below maxSize,
+// leaving some room so that the next writes don't evict again right away.
+// It returns the size of what is left
+func evict(dir string, maxSize int64) (int64, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, err
+	}
+
+	files := make([]os.FileInfo, 0, len(entries))
+	var size int64
+	for _, e := range entries {
+		info, err := e.Info()
+		if err != nil || info.IsDir() {
+			continue
+		}
+		files = append(files, info)
+		size += info.Size()
+	}
+	if size <= maxSize {
+		return size, nil
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].ModTime().Before(files[j].ModTime())
+	})
+	target := maxSize - maxSize/10
+	for _, f := range files {
+		if size <= target {
+			break
+		}
+		if os.Remove(filepath.Join(dir, f.Name())) == nil {
+			size -= f.Size()
+		}
+	}
+	return size, nil
+}
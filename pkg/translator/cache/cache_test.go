@@ -0,0 +1,174 @@
+package cache
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/fedeztk/got/pkg/translator"
+	"github.com/fedeztk/got/pkg/translator/lingvatranslate"
+	"github.com/fedeztk/got/pkg/translator/utils"
+)
+
+// countingBackend answers every translation with the same response, counting the calls
+type countingBackend struct {
+	translator.Backend
+	calls    int
+	ttsCalls int
+}
+
+func (b *countingBackend) Translate(ctx context.Context, text, source, target, engine string) (utils.BackendResponse, error) {
+	b.calls++
+	r := lingvatranslate.Response{Translation: "translated " + text}
+	r.Info.Examples = []string{"an example"}
+	return r.Result(), nil
+}
+
+func (b *countingBackend) TextToSpeech(ctx context.Context, text, language string) ([]byte, error) {
+	b.ttsCalls++
+	return []byte("audio of " + text), nil
+}
+
+func newTestBackend(t *testing.T, opts Options) (*Backend, *countingBackend) {
+	inner := &countingBackend{}
+	b := New(inner, "lingvatranslate", opts)
+	b.dir = t.TempDir()
+	b.audioDir = t.TempDir()
+	return b, inner
+}
+
+func TestCacheHit(t *testing.T) {
+	b, inner := newTestBackend(t, Options{TTL: time.Hour, MaxSize: 1 << 20})
+
+	first, err := b.Translate(context.Background(), "ciao", "it", "en", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := b.Translate(context.Background(), "ciao", "it", "en", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if inner.calls != 1 {
+		t.Errorf("expected the backend to be called once, got %d calls", inner.calls)
+	}
+	if Hit(first) || !Hit(second) {
+		t.Error("only the second translation should be served from the cache")
+	}
+	if first.PrettyPrint() != second.PrettyPrint() {
+		t.Error("the cached response differs from the original one")
+	}
+
+	if _, err := b.Translate(context.Background(), "ciao", "it", "de", ""); err != nil {
+		t.Fatal(err)
+	}
+	if inner.calls != 2 {
+		t.Error("a different target language should not be served from the cache")
+	}
+}
+
+func TestCacheExpiration(t *testing.T) {
+	b, inner := newTestBackend(t, Options{TTL: time.Hour, MaxSize: 1 << 20})
+
+	b.Translate(context.Background(), "ciao", "it", "en", "")
+	entries, _ := os.ReadDir(b.dir)
+	old := time.Now().Add(-2 * time.Hour)
+	for _, e := range entries {
+		os.Chtimes(b.dir+"/"+e.Name(), old, old)
+	}
+	b.Translate(context.Background(), "ciao", "it", "en", "")
+
+	if inner.calls != 2 {
+		t.Error("an expired translation should not be served from the cache")
+	}
+}
+
+func TestCacheEviction(t *testing.T) {
+	b, _ := newTestBackend(t, Options{TTL: time.Hour, MaxSize: 1})
+
+	b.Translate(context.Background(), "ciao", "it", "en", "")
+	b.Translate(context.Background(), "mondo", "it", "en", "")
+
+	entries, _ := os.ReadDir(b.dir)
+	if len(entries) != 0 {
+		t.Errorf("expected every translation to be evicted, %d left", len(entries))
+	}
+}
+
+func TestCacheEvictionKeepsNewest(t *testing.T) {
+	b, _ := newTestBackend(t, Options{TTL: time.Hour, MaxSize: 1 << 20})
+	texts := []string{"uno", "due", "tre", "quattro", "cinque"}
+
+	now := time.Now()
+	for i, text := range texts {
+		b.Translate(context.Background(), text, "it", "en", "")
+		// distinct modification times in the past, the oldest are evicted first
+		modTime := now.Add(time.Duration(i-len(texts)) * time.Minute)
+		os.Chtimes(b.path(entry{Backend: b.name, Source: "it", Target: "en", Text: text}), modTime, modTime)
+		if i == 1 {
+			b.opts.MaxSize = b.sizes[b.dir] * 2 // room for about two more translations
+		}
+	}
+
+	_, size, err := Kind{Name: "translations", Dir: b.dir}.Size()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if size > b.opts.MaxSize || size != b.sizes[b.dir] {
+		t.Errorf("expected a size of %d below %d, got %d", b.sizes[b.dir], b.opts.MaxSize, size)
+	}
+	if _, ok := b.load(b.path(entry{Backend: b.name, Source: "it", Target: "en", Text: "cinque"})); !ok {
+		t.Error("the newest translation should not be evicted")
+	}
+	if _, ok := b.load(b.path(entry{Backend: b.name, Source: "it", Target: "en", Text: "uno"})); ok {
+		t.Error("the oldest translation should be evicted")
+	}
+}
+
+func TestCacheAudio(t *testing.T) {
+	b, inner := newTestBackend(t, Options{TTL: time.Hour, MaxSize: 1 << 20, AudioMaxSize: 1 << 20})
+
+	for i := 0; i < 2; i++ {
+		audio, err := b.TextToSpeech(context.Background(), "ciao", "it")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(audio) != "audio of ciao" {
+			t.Errorf("unexpected audio %q", audio)
+		}
+	}
+	if inner.ttsCalls != 1 {
+		t.Errorf("expected the backend to be called once, got %d calls", inner.ttsCalls)
+	}
+
+	infos, err := Kind{Name: "audio", Dir: b.audioDir}.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(infos) != 1 || infos[0].Text != "ciao" || infos[0].Language != "it" {
+		t.Errorf("unexpected cached audio %+v", infos)
+	}
+}
+
+func TestKindSizeAndClear(t *testing.T) {
+	b, _ := newTestBackend(t, Options{TTL: time.Hour, MaxSize: 1 << 20})
+	b.Translate(context.Background(), "ciao", "it", "en", "")
+	b.Translate(context.Background(), "mondo", "it", "en", "")
+
+	k := Kind{Name: "translations", Dir: b.dir}
+	files, size, err := k.Size()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if files != 2 || size == 0 {
+		t.Errorf("expected 2 cached translations, got %d files of %d bytes", files, size)
+	}
+
+	if err := k.Clear(); err != nil {
+		t.Fatal(err)
+	}
+	if files, _, _ := k.Size(); files != 0 {
+		t.Errorf("expected an empty cache after clearing it, %d files left", files)
+	}
+}
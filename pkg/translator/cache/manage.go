@@ -0,0 +1,107 @@
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// Kind is one of the caches kept on disk
+type Kind struct {
+	Name string
+	Dir  string
+}
+
+// Kinds returns every cache kept on disk
+func Kinds() []Kind {
+	return []Kind{
+		{Name: "translations", Dir: TranslationsDir()},
+		{Name: "audio", Dir: AudioDir()},
+	}
+}
+
+// FindKind returns the cache called name
+func FindKind(name string) (Kind, error) {
+	for _, k := range Kinds() {
+		if k.Name == name {
+			return k, nil
+		}
+	}
+	return Kind{}, fmt.Errorf("unknown cache %q, could be: translations, audio", name)
+}
+
+// Info describes a cached translation or audio
+type Info struct {
+	Backend string    `json:"backend"`
+	Engine  string    `json:"engine,omitempty"`
+	Source  string    `json:"source,omitempty"`
+	Target  string    `json:"target,omitempty"`
+	Text    string    `json:"text"`
+	Size    int64     `json:"-"`
+	Time    time.Time `json:"-"` // when it was cached
+	// Language is the language the audio is spoken in, empty for translations
+	Language string `json:"language,omitempty"`
+}
+
+// List returns the entries cached in k, newest first
+func (k Kind) List() ([]Info, error) {
+	entries, err := os.ReadDir(k.Dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]Info, 0, len(entries))
+	for _, e := range entries {
+		fi, err := e.Info()
+		if err != nil || fi.IsDir() {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(k.Dir, e.Name()))
+		if err != nil {
+			continue
+		}
+		var i Info
+		if json.Unmarshal(data, &i) != nil {
+			continue // not written by got
+		}
+		i.Size, i.Time = fi.Size(), fi.ModTime()
+		infos = append(infos, i)
+	}
+
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].Time.After(infos[j].Time)
+	})
+	return infos, nil
+}
+
+// Size returns the number of files cached in k and their size in bytes
+func (k Kind) Size() (files int, size int64, err error) {
+	entries, err := os.ReadDir(k.Dir)
+	if os.IsNotExist(err) {
+		return 0, 0, nil
+	}
+	if err != nil {
+		return 0, 0, err
+	}
+
+	for _, e := range entries {
+		fi, err := e.Info()
+		if err != nil || fi.IsDir() {
+			continue
+		}
+		files++
+		size += fi.Size()
+	}
+	return files, size, nil
+}
+
+// Clear removes everything cached in k
+func (k Kind) Clear() error {
+	return os.RemoveAll(k.Dir)
+}
@@ -0,0 +1,200 @@
+package translator
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/fedeztk/got/pkg/translator/simplytranslate"
+	"github.com/fedeztk/got/pkg/translator/utils"
+)
+
+// Engines lists the engines supported by each backend, the first one is the default
+var Engines = map[string][]string{
+	"lingvatranslate": {"google"},
+	"simplytranslate": simplytranslate.Engines,
+	"libretranslate":  {"libre"},
+}
+
+// CheckEngine returns an error if backend does not support engine
+func CheckEngine(backend, engine string) error {
+	engines, ok := Engines[backend]
+	if !ok {
+		return fmt.Errorf("backend %s not supported, please use one of the following: %s", backend, strings.Join(Backends, ", "))
+	}
+	for _, e := range engines {
+		if e == engine {
+			return nil
+		}
+	}
+	return fmt.Errorf("engine %s not supported by %s, please use one of the following: %s", engine, backend, strings.Join(engines, ", "))
+}
+
+// Engine is one of the engines of a backend
+type Engine struct {
+	Backend string
+	Engine  string
+}
+
+// AllEngines returns every engine of every backend, in the order of Backends
+func AllEngines() []Engine {
+	all := []Engine{}
+	for _, b := range Backends {
+		for _, e := range Engines[b] {
+			all = append(all, Engine{Backend: b, Engine: e})
+		}
+	}
+	return all
+}
+
+func (e Engine) String() string {
+	return e.Backend + ":" + e.Engine
+}
+
+// ParseEngines parses a list of backend:engine, the engine can be omitted
+// to use the default one of the backend, e.g. simplytranslate:reverso,lingvatranslate
+func ParseEngines(list []string) ([]Engine, error) {
+	engines := make([]Engine, 0, len(list))
+	for _, item := range list {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		e := Engine{Backend: item}
+		if i := strings.Index(item, ":"); i >= 0 {
+			e = Engine{Backend: item[:i], Engine: item[i+1:]}
+		}
+		if e.Engine == "" && len(Engines[e.Backend]) > 0 {
+			e.Engine = Engines[e.Backend][0]
+		}
+		if err := CheckEngine(e.Backend, e.Engine); err != nil {
+			return nil, err
+		}
+		engines = append(engines, e)
+	}
+	return engines, nil
+}
+
+// Comparison is the translation of a text made by one of the compared engines
+type Comparison struct {
+	Engine   Engine
+	Response utils.BackendResponse
+	Err      error
+}
+
+// Compare translates text with every engine at the same time, backends maps
+// the name of every backend of engines to the backend itself. The
+// comparisons follow the order of engines
+func Compare(ctx context.Context, backends map[string]Backend, engines []Engine, text, source, target string) []Comparison {
+	comparisons := make([]Comparison, len(engines))
+	parallel(len(engines), len(engines), func(i int) {
+		e := engines[i]
+		comparisons[i].Engine = e
+		b, ok := backends[e.Backend]
+		if !ok {
+			comparisons[i].Err = fmt.Errorf("backend %s not available", e.Backend)
+			return
+		}
+		comparisons[i].Response, comparisons[i].Err = TranslateText(ctx, b, text, source, target, e.Engine)
+	})
+	return comparisons
+}
+
+// Word is a word of a compared translation, it Differs when at least one of
+// the other translations does not contain it
+type Word struct {
+	Text    string
+	Differs bool
+}
+
+// CompareWords splits every successful translation in words, marking the ones
+// the engines disagree on. Failed comparisons have no words
+func CompareWords(comparisons []Comparison) [][]Word {
+	vocabularies := make([]map[string]bool, len(comparisons))
+	for i, c := range comparisons {
+		if c.Err != nil {
+			continue
+		}
+		vocabularies[i] = map[string]bool{}
+		for _, w := range strings.Fields(c.Response.ShortTranslatedText()) {
+			vocabularies[i][normalizeWord(w)] = true
+		}
+	}
+
+	words := make([][]Word, len(comparisons))
+	for i, c := range comparisons {
+		if c.Err != nil {
+			continue
+		}
+		for _, w := range strings.Fields(c.Response.ShortTranslatedText()) {
+			differs := false
+			for j, vocabulary := range vocabularies {
+				if vocabulary != nil && j != i && !vocabulary[normalizeWord(w)] {
+					differs = true
+					break
+				}
+			}
+			words[i] = append(words[i], Word{Text: w, Differs: differs})
+		}
+	}
+	return words
+}
+
+// normalizeWord ignores case and punctuation, that engines often disagree on
+func normalizeWord(w string) string {
+	return strings.ToLower(strings.TrimFunc(w, unicode.IsPunct))
+}
+
+// Agree reports whether the engines agree on every word
+func Agree(words [][]Word) bool {
+	for _, ws := range words {
+		for _, w := range ws {
+			if w.Differs {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// PrettyPrintComparisons renders the translations of every engine next to
+// each other, highlighting the words they disagree on
+func PrettyPrintComparisons(comparisons []Comparison) string {
+	width := 0
+	for _, c := range comparisons {
+		if l := len(c.Engine.String()); l > width {
+			width = l
+		}
+	}
+
+	words := CompareWords(comparisons)
+	builder := strings.Builder{}
+	answered := 0
+	for i, c := range comparisons {
+		builder.WriteString(utils.ListItem.Render(fmt.Sprintf("%-*s", width, c.Engine)) + "  ")
+		if c.Err != nil {
+			builder.WriteString(c.Err.Error() + "\n")
+			continue
+		}
+		answered++
+		rendered := make([]string, 0, len(words[i]))
+		for _, w := range words[i] {
+			if w.Differs {
+				rendered = append(rendered, utils.Highlight.Render(w.Text))
+			} else {
+				rendered = append(rendered, w.Text)
+			}
+		}
+		builder.WriteString(strings.Join(rendered, " ") + "\n")
+	}
+
+	switch {
+	case answered < 2: // nothing to compare
+	case Agree(words):
+		builder.WriteString("\n" + utils.IndentTwo.Render("The engines agree") + "\n")
+	default:
+		builder.WriteString("\n" + utils.IndentTwo.Render("The engines disagree on the highlighted words") + "\n")
+	}
+	return builder.String()
+}
@@ -0,0 +1,80 @@
+package translator
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/fedeztk/got/pkg/translator/utils"
+)
+
+// engineBackend translates every text with a fixed answer per engine
+type engineBackend struct {
+	Backend
+	answers map[string]string
+}
+
+func (b engineBackend) Translate(ctx context.Context, text, source, target, engine string) (utils.BackendResponse, error) {
+	answer, ok := b.answers[engine]
+	if !ok {
+		return nil, errors.New("engine down")
+	}
+	return utils.Result{Translation: answer}, nil
+}
+
+func TestParseEngines(t *testing.T) {
+	engines, err := ParseEngines([]string{"simplytranslate:reverso", " lingvatranslate", ""})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Engine{{"simplytranslate", "reverso"}, {"lingvatranslate", "google"}}
+	if !reflect.DeepEqual(engines, want) {
+		t.Errorf("expected %v, got %v", want, engines)
+	}
+
+	for _, invalid := range []string{"lingvatranslate:reverso", "deepltranslate"} {
+		if _, err := ParseEngines([]string{invalid}); err == nil {
+			t.Errorf("expected an error for %s", invalid)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	backends := map[string]Backend{
+		"simplytranslate": engineBackend{answers: map[string]string{
+			"google":  "The cat is on the table.",
+			"reverso": "the cat is on the desk",
+		}},
+	}
+	engines := []Engine{{"simplytranslate", "google"}, {"simplytranslate", "reverso"}, {"simplytranslate", "iciba"}, {"lingvatranslate", "google"}}
+
+	comparisons := Compare(context.Background(), backends, engines, "Il gatto è sul tavolo", "it", "en")
+	for i, c := range comparisons {
+		if c.Engine != engines[i] {
+			t.Errorf("comparison %d is for %s instead of %s", i, c.Engine, engines[i])
+		}
+		if failed := c.Err != nil; failed != (i >= 2) {
+			t.Errorf("%s: unexpected error %v", c.Engine, c.Err)
+		}
+	}
+
+	words := CompareWords(comparisons)
+	differing := map[string]bool{}
+	for _, ws := range words {
+		for _, w := range ws {
+			if w.Differs {
+				differing[w.Text] = true
+			}
+		}
+	}
+	if !reflect.DeepEqual(differing, map[string]bool{"table.": true, "desk": true}) {
+		t.Errorf("unexpected differing words %v", differing)
+	}
+	if Agree(words) {
+		t.Error("the engines should disagree")
+	}
+	if !Agree(CompareWords(comparisons[:1])) {
+		t.Error("a single engine always agrees with itself")
+	}
+}
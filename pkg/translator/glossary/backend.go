@@ -0,0 +1,133 @@
+package glossary
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/fedeztk/got/pkg/translator"
+	"github.com/fedeztk/got/pkg/translator/utils"
+)
+
+// Backend wraps a translator.Backend enforcing the glossaries of a Set, the
+// other methods are passed through
+type Backend struct {
+	translator.Backend
+	set *Set
+}
+
+// NewBackend returns b translating with the glossaries of set
+func NewBackend(b translator.Backend, set *Set) *Backend {
+	return &Backend{Backend: b, set: set}
+}
+
+func (b *Backend) Translate(ctx context.Context, text, source, target, engine string) (utils.BackendResponse, error) {
+	if source != "auto" || !b.set.HasTarget(target) {
+		return b.translate(ctx, text, source, target, engine, "")
+	}
+
+	// the glossary depends on the language, which must be known beforehand
+	detected, err := b.Backend.Detect(ctx, text)
+	if err == nil {
+		return b.translate(ctx, text, detected, target, engine, detected)
+	}
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	// backends like simplytranslate can't detect alone, the language comes
+	// with the translation, which is redone if the glossary has terms in text
+	r, err := b.Backend.Translate(ctx, text, source, target, engine)
+	if err != nil || r.DetectedLanguage() == "" {
+		return r, err
+	}
+	g, err := b.set.Get(r.DetectedLanguage(), target)
+	if err != nil {
+		return nil, err
+	}
+	if len(g.Hits(text)) == 0 {
+		return r, nil
+	}
+	return b.translate(ctx, text, r.DetectedLanguage(), target, engine, r.DetectedLanguage())
+}
+
+// translate translates text enforcing the glossary of the language pair,
+// detected is the source language when it was detected beforehand
+func (b *Backend) translate(ctx context.Context, text, source, target, engine, detected string) (utils.BackendResponse, error) {
+	g, err := b.set.Get(source, target)
+	if err != nil {
+		return nil, err
+	}
+
+	masked, p := g.Mask(text)
+	r, err := b.Backend.Translate(ctx, masked, source, target, engine)
+	if err != nil || (p.Len() == 0 && detected == "") {
+		return r, err
+	}
+	return response{r, p, detected}, nil
+}
+
+// response is a translation with the enforced terms put back in place
+type response struct {
+	utils.BackendResponse
+	placeholders *translator.Placeholders
+	detected     string // source language detected before translating, if any
+}
+
+func (r response) Result() utils.Result {
+	result := r.BackendResponse.Result()
+	if r.detected != "" {
+		result.Detected = r.detected
+	}
+	result.Translation = r.placeholders.Restore(result.Translation)
+	alternatives := make([]string, len(result.Alternatives))
+	for i, alternative := range result.Alternatives {
+		alternatives[i] = r.placeholders.Restore(alternative)
+	}
+	if len(alternatives) > 0 {
+		result.Alternatives = alternatives
+	}
+	return result
+}
+
+func (r response) ShortTranslatedText() string {
+	return r.placeholders.Restore(r.BackendResponse.ShortTranslatedText())
+}
+
+func (r response) DetectedLanguage() string {
+	if r.detected != "" {
+		return r.detected
+	}
+	return r.BackendResponse.DetectedLanguage()
+}
+
+func (r response) PrettyPrint() string {
+	return r.Result().PrettyPrint()
+}
+
+func (r response) Unwrap() utils.BackendResponse {
+	return r.BackendResponse
+}
+
+// Highlight returns r with the translation of terms highlighted when pretty printed
+func Highlight(r utils.BackendResponse, terms []Term) utils.BackendResponse {
+	if len(terms) == 0 {
+		return r
+	}
+	return highlighted{r, alternation(terms, func(t Term) string { return t.Target }, false)}
+}
+
+type highlighted struct {
+	utils.BackendResponse
+	pattern *regexp.Regexp
+}
+
+func (h highlighted) PrettyPrint() string {
+	result := h.Result()
+	result.Translation = h.pattern.ReplaceAllStringFunc(result.Translation, func(term string) string {
+		return utils.Highlight.Render(term)
+	})
+	return result.PrettyPrint()
+}
+
+func (h highlighted) Unwrap() utils.BackendResponse {
+	return h.BackendResponse
+}
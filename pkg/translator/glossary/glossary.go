@@ -0,0 +1,300 @@
+// Package glossary enforces the translation of product names and domain terms:
+// the terms of a language pair are masked before the text reaches the engine
+// and replaced with their enforced translation afterwards
+package glossary
+
+import (
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/fedeztk/got/pkg/translator"
+)
+
+// Term is a source term and its enforced translation
+type Term struct {
+	Source string
+	Target string
+}
+
+// Glossary holds the terms of a language pair
+type Glossary struct {
+	Terms   []Term
+	pattern *regexp.Regexp // matches any source term, longest first
+}
+
+// New returns the glossary made of terms, ignoring the ones with an empty side
+func New(terms []Term) *Glossary {
+	g := &Glossary{}
+	for _, t := range terms {
+		t.Source, t.Target = strings.TrimSpace(t.Source), strings.TrimSpace(t.Target)
+		if t.Source != "" && t.Target != "" {
+			g.Terms = append(g.Terms, t)
+		}
+	}
+	if len(g.Terms) > 0 {
+		g.pattern = alternation(g.Terms, func(t Term) string { return t.Source }, true)
+	}
+	return g
+}
+
+// alternation returns a regexp matching any of the terms, trying the longest ones first
+func alternation(terms []Term, side func(Term) string, ignoreCase bool) *regexp.Regexp {
+	quoted := make([]string, len(terms))
+	for i, t := range terms {
+		quoted[i] = regexp.QuoteMeta(side(t))
+	}
+	sort.SliceStable(quoted, func(i, j int) bool { return len(quoted[i]) > len(quoted[j]) })
+	flags := ""
+	if ignoreCase {
+		flags = "(?i)"
+	}
+	return regexp.MustCompile(flags + "(?:" + strings.Join(quoted, "|") + ")")
+}
+
+// Load reads the terms from source to target of the glossary at path, either a
+// CSV file with the source term in the first column and its translation in the
+// second, or a TBX file
+func Load(path, source, target string) (*Glossary, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var terms []Term
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		terms, err = readCSV(f, source, target)
+	case ".tbx", ".xml":
+		terms, err = readTBX(f, source, target)
+	default:
+		return nil, errors.New("Unable to load the glossary " + path + "! Only CSV and TBX files are supported")
+	}
+	if err != nil {
+		return nil, errors.New("Unable to load the glossary " + path + "! " + err.Error())
+	}
+	return New(terms), nil
+}
+
+// readCSV reads the terms of a CSV file, lines starting with # are comments and
+// a first row naming the columns (source,target or the language codes) is skipped
+func readCSV(r io.Reader, source, target string) ([]Term, error) {
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	terms := []Term{}
+	for first := true; ; first = false {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return terms, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(record) < 2 {
+			continue
+		}
+		if first && (isHeader(record, "source", "target") || isHeader(record, source, target)) {
+			continue
+		}
+		terms = append(terms, Term{Source: record[0], Target: record[1]})
+	}
+}
+
+func isHeader(record []string, source, target string) bool {
+	return strings.EqualFold(strings.TrimSpace(record[0]), source) && strings.EqualFold(strings.TrimSpace(record[1]), target)
+}
+
+// readTBX reads the terms of a TBX file, both the termEntry/langSet layout of
+// TBX 2 and the conceptEntry/langSec one of TBX 3. The first term of each
+// language in an entry is the one used
+func readTBX(r io.Reader, source, target string) ([]Term, error) {
+	decoder := xml.NewDecoder(r)
+	terms := []Term{}
+
+	var entry map[string]string // language to term of the current entry
+	var lang string
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return terms, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "termEntry", "conceptEntry":
+				entry = map[string]string{}
+			case "langSet", "langSec":
+				lang = ""
+				for _, a := range t.Attr {
+					if a.Name.Local == "lang" {
+						lang = a.Value
+					}
+				}
+			case "term":
+				var term string
+				if err := decoder.DecodeElement(&term, &t); err != nil {
+					return nil, err
+				}
+				if entry != nil && lang != "" {
+					if _, ok := entry[lang]; !ok {
+						entry[lang] = term
+					}
+				}
+			}
+		case xml.EndElement:
+			if t.Name.Local != "termEntry" && t.Name.Local != "conceptEntry" {
+				continue
+			}
+			if s, t := find(entry, source), find(entry, target); s != "" && t != "" {
+				terms = append(terms, Term{Source: s, Target: t})
+			}
+			entry = nil
+		}
+	}
+}
+
+// find returns the term of lang in entry, regional variants like en-US are
+// used when there is no exact match
+func find(entry map[string]string, lang string) string {
+	for l, term := range entry {
+		if strings.EqualFold(l, lang) {
+			return term
+		}
+	}
+	for l, term := range entry {
+		if base := strings.SplitN(l, "-", 2)[0]; strings.EqualFold(base, lang) {
+			return term
+		}
+	}
+	return ""
+}
+
+// each calls f with the position and the term of every whole word occurrence
+// of a source term in text
+func (g *Glossary) each(text string, f func(start, end int, t Term)) {
+	if g == nil || g.pattern == nil {
+		return
+	}
+	for _, match := range g.pattern.FindAllStringIndex(text, -1) {
+		start, end := match[0], match[1]
+		if !boundary(text, start, end) {
+			continue
+		}
+		for _, t := range g.Terms {
+			if strings.EqualFold(t.Source, text[start:end]) {
+				f(start, end, t)
+				break
+			}
+		}
+	}
+}
+
+// boundary reports whether text[start:end] is not part of a longer word
+func boundary(text string, start, end int) bool {
+	before, _ := utf8.DecodeLastRuneInString(text[:start])
+	after, _ := utf8.DecodeRuneInString(text[end:])
+	return !isWord(before) && !isWord(after)
+}
+
+func isWord(r rune) bool {
+	return r != utf8.RuneError && (unicode.IsLetter(r) || unicode.IsDigit(r))
+}
+
+// Mask replaces every source term in text with a placeholder, restoring the
+// placeholders puts the enforced translation of the term in its place
+func (g *Glossary) Mask(text string) (string, *translator.Placeholders) {
+	p := &translator.Placeholders{}
+	builder := strings.Builder{}
+	last := 0
+	g.each(text, func(start, end int, t Term) {
+		builder.WriteString(text[last:start])
+		builder.WriteString(p.Add(t.Target))
+		last = end
+	})
+	builder.WriteString(text[last:])
+	return builder.String(), p
+}
+
+// Hits returns the terms found in text, once each
+func (g *Glossary) Hits(text string) []Term {
+	hits := []Term{}
+	seen := map[Term]bool{}
+	g.each(text, func(_, _ int, t Term) {
+		if !seen[t] {
+			seen[t] = true
+			hits = append(hits, t)
+		}
+	})
+	return hits
+}
+
+// Set lazily loads the glossaries of the language pairs it is given
+type Set struct {
+	files  map[string]string // glossary file of each pair, like en-it
+	mu     sync.Mutex
+	loaded map[string]*Glossary
+}
+
+// NewSet returns the set of the glossary files of each language pair, keyed
+// like en-it
+func NewSet(files map[string]string) *Set {
+	lower := make(map[string]string, len(files))
+	for pair, path := range files {
+		lower[strings.ToLower(pair)] = path
+	}
+	return &Set{files: lower, loaded: map[string]*Glossary{}}
+}
+
+// Get returns the glossary from source to target, nil if there is none
+func (s *Set) Get(source, target string) (*Glossary, error) {
+	if s == nil {
+		return nil, nil
+	}
+	pair := strings.ToLower(source + "-" + target)
+	path, ok := s.files[pair]
+	if !ok {
+		return nil, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if g, ok := s.loaded[pair]; ok {
+		return g, nil
+	}
+	g, err := Load(path, source, target)
+	if err != nil {
+		return nil, err
+	}
+	s.loaded[pair] = g
+	return g, nil
+}
+
+// HasTarget reports whether a glossary translates to target
+func (s *Set) HasTarget(target string) bool {
+	if s == nil {
+		return false
+	}
+	for pair := range s.files {
+		if strings.HasSuffix(pair, "-"+strings.ToLower(target)) {
+			return true
+		}
+	}
+	return false
+}
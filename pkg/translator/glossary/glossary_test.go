@@ -0,0 +1,184 @@
+package glossary
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/fedeztk/got/pkg/translator"
+	"github.com/fedeztk/got/pkg/translator/utils"
+)
+
+func write(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadCSV(t *testing.T) {
+	path := write(t, "en-it.csv", "source,target\n# product names\nGot Cloud,Got Cloud\n\"pull request\", richiesta di pull\nbroken\n")
+	g, err := Load(path, "en", "it")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Term{{"Got Cloud", "Got Cloud"}, {"pull request", "richiesta di pull"}}
+	if !reflect.DeepEqual(g.Terms, want) {
+		t.Errorf("got %v, want %v", g.Terms, want)
+	}
+}
+
+func TestLoadTBX(t *testing.T) {
+	path := write(t, "terms.tbx", `<?xml version="1.0"?>
+<martif type="TBX" xml:lang="en">
+  <text><body>
+    <termEntry id="1">
+      <langSet xml:lang="en-US"><tig><term>dashboard</term></tig></langSet>
+      <langSet xml:lang="it"><tig><term>cruscotto</term></tig><tig><term>pannello</term></tig></langSet>
+    </termEntry>
+    <termEntry id="2">
+      <langSet xml:lang="en"><tig><term>widget</term></tig></langSet>
+      <langSet xml:lang="de"><tig><term>Widget</term></tig></langSet>
+    </termEntry>
+  </body></text>
+</martif>`)
+	g, err := Load(path, "en", "it")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Term{{"dashboard", "cruscotto"}}
+	if !reflect.DeepEqual(g.Terms, want) {
+		t.Errorf("got %v, want %v", g.Terms, want)
+	}
+}
+
+func TestLoadUnsupported(t *testing.T) {
+	if _, err := Load(write(t, "terms.txt", "a,b"), "en", "it"); err == nil {
+		t.Error("expected an error for an unsupported file")
+	}
+}
+
+func TestMask(t *testing.T) {
+	g := New([]Term{{"Got", "Got"}, {"Got Cloud", "Got Cloud"}, {"pull request", "richiesta di pull"}})
+
+	masked, p := g.Mask("Open a Pull Request on Got Cloud, not on Gotham or Got.")
+	if want := "Open a [[0]] on [[1]], not on Gotham or [[2]]."; masked != want {
+		t.Errorf("got %q, want %q", masked, want)
+	}
+	restored := p.Restore("Apri una [[ 0 ]] su [[1]], non su Gotham o [[2]] [[7]].")
+	if want := "Apri una richiesta di pull su Got Cloud, non su Gotham o Got [[7]]."; restored != want {
+		t.Errorf("got %q, want %q", restored, want)
+	}
+
+	hits := g.Hits("got and GOT cloud")
+	if want := []Term{{"Got", "Got"}, {"Got Cloud", "Got Cloud"}}; !reflect.DeepEqual(hits, want) {
+		t.Errorf("got %v, want %v", hits, want)
+	}
+}
+
+// echoBackend "translates" by upper casing the text, placeholders included
+type echoBackend struct {
+	translator.Backend
+	sources []string
+}
+
+func (b *echoBackend) Translate(ctx context.Context, text, source, target, engine string) (utils.BackendResponse, error) {
+	b.sources = append(b.sources, source)
+	return utils.Result{Translation: strings.ToUpper(text), Alternatives: []string{text}}, nil
+}
+
+func (b *echoBackend) Detect(ctx context.Context, text string) (string, error) {
+	return "en", nil
+}
+
+func TestBackend(t *testing.T) {
+	echo := &echoBackend{}
+	b := NewBackend(echo, NewSet(map[string]string{
+		"EN-it": write(t, "en-it.csv", "Got Cloud,Got Cloud\n"),
+	}))
+
+	r, err := b.Translate(context.Background(), "welcome to got cloud", "auto", "it", "google")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := r.ShortTranslatedText(), "WELCOME TO Got Cloud"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if got, want := r.Result().Alternatives, []string{"welcome to Got Cloud"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if got := r.DetectedLanguage(); got != "en" {
+		t.Errorf("got detected language %q, want en", got)
+	}
+
+	// no glossary for the pair, the text is sent as is
+	r, err = b.Translate(context.Background(), "got cloud", "auto", "de", "google")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := r.ShortTranslatedText(), "GOT CLOUD"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if want := []string{"en", "auto"}; !reflect.DeepEqual(echo.sources, want) {
+		t.Errorf("got sources %v, want %v", echo.sources, want)
+	}
+}
+
+// undetectingBackend can't detect languages, it tells the detected one with
+// the translations of auto
+type undetectingBackend struct {
+	echoBackend
+}
+
+func (b *undetectingBackend) Translate(ctx context.Context, text, source, target, engine string) (utils.BackendResponse, error) {
+	b.sources = append(b.sources, source)
+	r := utils.Result{Translation: strings.ToUpper(text)}
+	if source == "auto" {
+		r.Detected = "en"
+	}
+	return r, nil
+}
+
+func (b *undetectingBackend) Detect(ctx context.Context, text string) (string, error) {
+	return "", errors.New("language detection is not supported")
+}
+
+func TestBackendWithoutDetection(t *testing.T) {
+	undetecting := &undetectingBackend{}
+	b := NewBackend(undetecting, NewSet(map[string]string{
+		"en-it": write(t, "en-it.csv", "Got Cloud,Got Cloud\n"),
+	}))
+
+	for _, tc := range []struct {
+		text, want string
+		sources    []string
+	}{
+		{"welcome to got cloud", "WELCOME TO Got Cloud", []string{"auto", "en"}},
+		{"welcome", "WELCOME", []string{"auto"}},
+	} {
+		undetecting.sources = nil
+		r, err := b.Translate(context.Background(), tc.text, "auto", "it", "google")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := r.ShortTranslatedText(); got != tc.want {
+			t.Errorf("got %q, want %q", got, tc.want)
+		}
+		if !reflect.DeepEqual(undetecting.sources, tc.sources) {
+			t.Errorf("got sources %v, want %v", undetecting.sources, tc.sources)
+		}
+	}
+}
+
+func TestBackendBrokenGlossary(t *testing.T) {
+	b := NewBackend(&echoBackend{}, NewSet(map[string]string{"en-it": filepath.Join(t.TempDir(), "missing.csv")}))
+	if _, err := b.Translate(context.Background(), "text", "en", "it", "google"); err == nil {
+		t.Error("expected an error for a missing glossary")
+	}
+}
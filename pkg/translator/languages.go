@@ -0,0 +1,81 @@
+package translator
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fedeztk/got/pkg/translator/utils"
+)
+
+// languagesTTL is how long the cached list of languages of a backend is considered fresh
+const languagesTTL = 7 * 24 * time.Hour
+
+// Languages returns the languages supported by the backend named name using
+// instances, the default one if empty. The list is read from the on-disk cache
+// when fresh, otherwise it is fetched from the backend and cached. If fetching
+// fails the stale cache, or the static list of languages when there is none,
+// is returned along with the error
+func Languages(ctx context.Context, b Backend, name string, instances []string) ([]utils.Language, error) {
+	path := languagesCachePath(name, instances)
+
+	cached, modTime, cacheErr := readLanguagesCache(path)
+	if cacheErr == nil && time.Since(modTime) < languagesTTL {
+		return cached, nil
+	}
+
+	languages, err := b.Languages(ctx)
+	if err != nil || len(languages) == 0 {
+		if cacheErr == nil {
+			return cached, err
+		}
+		return utils.GetLanguageList(), err
+	}
+
+	writeLanguagesCache(path, languages)
+	return languages, nil
+}
+
+// languagesCachePath returns the cache of the languages of a backend, every
+// list of instances has its own as they may support different languages
+func languagesCachePath(name string, instances []string) string {
+	if len(instances) > 0 {
+		sum := sha256.Sum256([]byte(strings.Join(instances, "\n")))
+		name += "-" + hex.EncodeToString(sum[:8])
+	}
+	return filepath.Join(utils.CacheDir("languages"), name+".json")
+}
+
+func readLanguagesCache(path string) ([]utils.Language, time.Time, error) {
+	var languages []utils.Language
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	if err := json.Unmarshal(data, &languages); err != nil {
+		return nil, time.Time{}, err
+	}
+	return languages, info.ModTime(), nil
+}
+
+// writeLanguagesCache is best effort, failing to cache only means fetching again
+func writeLanguagesCache(path string, languages []utils.Language) {
+	data, err := json.Marshal(languages)
+	if err != nil {
+		return
+	}
+	if os.MkdirAll(filepath.Dir(path), os.ModePerm) != nil {
+		return
+	}
+	os.WriteFile(path, data, 0o644)
+}
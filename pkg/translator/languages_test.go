@@ -0,0 +1,89 @@
+package translator
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/fedeztk/got/pkg/translator/utils"
+)
+
+// languagesBackend lists languages, or fails when languages is empty
+type languagesBackend struct {
+	Backend
+	languages []utils.Language
+	calls     int
+}
+
+func (b *languagesBackend) Languages(ctx context.Context) ([]utils.Language, error) {
+	b.calls++
+	if len(b.languages) == 0 {
+		return nil, errors.New("backend down")
+	}
+	return b.languages, nil
+}
+
+func TestLanguagesCache(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	b := &languagesBackend{languages: []utils.Language{{Code: "it", Name: "Italian"}}}
+
+	for i := 0; i < 2; i++ {
+		languages, err := Languages(context.Background(), b, "libretranslate", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(languages) != 1 || languages[0].Code != "it" {
+			t.Errorf("unexpected languages %v", languages)
+		}
+	}
+	if b.calls != 1 {
+		t.Errorf("expected a fresh cache to be used, got %d calls", b.calls)
+	}
+
+	if _, err := Languages(context.Background(), b, "libretranslate", []string{"https://libre.example.org"}); err != nil {
+		t.Fatal(err)
+	}
+	if b.calls != 2 {
+		t.Error("other instances should not share the cache")
+	}
+}
+
+func TestLanguagesStaleCache(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	b := &languagesBackend{languages: []utils.Language{{Code: "it", Name: "Italian"}}}
+	Languages(context.Background(), b, "libretranslate", nil)
+
+	stale := time.Now().Add(-2 * languagesTTL)
+	os.Chtimes(languagesCachePath("libretranslate", nil), stale, stale)
+	b.languages = []utils.Language{{Code: "de", Name: "German"}}
+	languages, err := Languages(context.Background(), b, "libretranslate", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.calls != 2 || len(languages) != 1 || languages[0].Code != "de" {
+		t.Errorf("expected a stale cache to be fetched again, got %v after %d calls", languages, b.calls)
+	}
+
+	os.Chtimes(languagesCachePath("libretranslate", nil), stale, stale)
+	b.languages = nil
+	languages, err = Languages(context.Background(), b, "libretranslate", nil)
+	if err == nil {
+		t.Error("expected the error of the backend")
+	}
+	if len(languages) != 1 || languages[0].Code != "de" {
+		t.Errorf("expected the stale cache when fetching fails, got %v", languages)
+	}
+}
+
+func TestLanguagesWithoutCache(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	languages, err := Languages(context.Background(), &languagesBackend{}, "libretranslate", nil)
+	if err == nil {
+		t.Error("expected the error of the backend")
+	}
+	if len(languages) != len(utils.GetLanguageList()) {
+		t.Errorf("expected the static list of languages, got %d languages", len(languages))
+	}
+}
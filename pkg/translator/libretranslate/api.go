@@ -0,0 +1,163 @@
+package libretranslate
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/fedeztk/got/pkg/translator/utils"
+)
+
+type Response struct {
+	TranslatedText string `json:"translatedText"`
+	Detected       struct {
+		Confidence float64 `json:"confidence,omitempty"`
+		Language   string  `json:"language,omitempty"`
+	} `json:"detectedLanguage,omitempty"`
+	Alternatives []string `json:"alternatives,omitempty"`
+}
+
+type errorResponse struct {
+	Error string `json:"error,omitempty"`
+}
+
+// DefaultInstance is used when no instance is configured, it requires an api key
+const DefaultInstance = "https://libretranslate.com"
+
+type LibreTranslate struct {
+	client       http.Client
+	instances    *utils.Instances
+	apiKey       string
+	alternatives int
+}
+
+// New returns a libretranslate backend that uses the given instances,
+// or DefaultInstance if none is given.
+// A zero timeout means no timeout
+func New(apiKey string, timeout time.Duration, instances ...string) LibreTranslate {
+	return LibreTranslate{
+		client:       http.Client{Timeout: timeout},
+		instances:    utils.NewInstances(instances, DefaultInstance),
+		apiKey:       apiKey,
+		alternatives: 3,
+	}
+}
+
+func (b LibreTranslate) Translate(ctx context.Context, text, source, target, engine string) (utils.BackendResponse, error) {
+	r := Response{}
+
+	if text == "" {
+		return r.Result(), nil
+	}
+	if source == "" {
+		source = "auto"
+	}
+	if target == "" {
+		target = "en"
+	}
+
+	// languages are validated by the server, since every instance can
+	// be configured with a different set of models
+	body := map[string]any{
+		"q":            text,
+		"source":       source,
+		"target":       target,
+		"format":       "text",
+		"alternatives": b.alternatives,
+	}
+	err := b.post(ctx, "/translate", body, &r)
+	if err != nil {
+		return r.Result(), fmt.Errorf("Unable to translate! %w", err)
+	}
+
+	return r.Result(), nil
+}
+
+func (b LibreTranslate) TextToSpeech(ctx context.Context, text, lang string) ([]byte, error) {
+	return nil, errors.New("text to speech is not supported by libretranslate")
+}
+
+// Detect returns the code of the language the text is most likely written in
+func (b LibreTranslate) Detect(ctx context.Context, text string) (string, error) {
+	var r []struct {
+		Confidence float64 `json:"confidence"`
+		Language   string  `json:"language"`
+	}
+
+	if text == "" {
+		return "", nil
+	}
+
+	err := b.post(ctx, "/detect", map[string]any{"q": text}, &r)
+	if err != nil {
+		return "", fmt.Errorf("Unable to detect language! %w", err)
+	}
+	if len(r) == 0 {
+		return "", errors.New("Unable to detect language! Empty response received from server")
+	}
+
+	return r[0].Language, nil
+}
+
+// Languages returns the languages supported by the instance
+func (b LibreTranslate) Languages(ctx context.Context) ([]utils.Language, error) {
+	var r []utils.Language
+
+	res, err := b.instances.Do(&b.client, func(baseURL string) (*http.Request, error) {
+		return http.NewRequestWithContext(ctx, "GET", baseURL+"/languages", nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != 200 {
+		return nil, errors.New("Unable to get languages! Status code received from server: " + res.Status)
+	}
+
+	err = json.NewDecoder(res.Body).Decode(&r)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]utils.Language{utils.AutoDetect}, r...), nil
+}
+
+// post sends body as json to the given endpoint, adding the api key if set,
+// and decodes the json response into v
+func (b LibreTranslate) post(ctx context.Context, endpoint string, body map[string]any, v any) error {
+	if b.apiKey != "" {
+		body["api_key"] = b.apiKey
+	}
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	res, err := b.instances.Do(&b.client, func(baseURL string) (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, "POST", baseURL+endpoint, bytes.NewReader(payload))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		return req, nil
+	})
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != 200 {
+		e := errorResponse{}
+		if json.NewDecoder(res.Body).Decode(&e) == nil && e.Error != "" {
+			return errors.New("Error received from server: " + e.Error)
+		}
+		return errors.New("Status code received from server: " + res.Status)
+	}
+
+	return json.NewDecoder(res.Body).Decode(v)
+}
@@ -0,0 +1,105 @@
+package libretranslate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"testing"
+	"time"
+)
+
+// the public instance requires an api key, tests are skipped unless
+// either a key or a (self hosted) instance is provided
+func newTestBackend(t *testing.T) LibreTranslate {
+	apiKey, instance := os.Getenv("LIBRETRANSLATE_API_KEY"), os.Getenv("LIBRETRANSLATE_URL")
+	if apiKey == "" && instance == "" {
+		t.Skip("neither LIBRETRANSLATE_API_KEY nor LIBRETRANSLATE_URL are set")
+	}
+	return New(apiKey, 10*time.Second, instance)
+}
+
+func TestTranslation(t *testing.T) {
+	testCases := []struct {
+		text, source, target string
+	}{
+		{"coperchio", "it", "en"},
+		{"Hello World!", "en", "it"},
+		{"corsa", "auto", "en"},
+		{"exit", "en", "it"},
+	}
+	b := newTestBackend(t)
+	for _, tc := range testCases {
+		res, err := b.Translate(context.Background(), tc.text, tc.source, tc.target, "")
+		if err != nil {
+			t.Error(err)
+		}
+		resJSON, err := json.MarshalIndent(res, "", "    ")
+		if err != nil {
+			t.Error(err)
+		}
+		t.Log(string(resJSON))
+	}
+}
+
+func TestPrettyPrint(t *testing.T) {
+	testCases := []struct {
+		text, source, target string
+	}{
+		{"coperchio", "it", "en"},
+		{"Hello World!", "auto", "it"},
+	}
+	b := newTestBackend(t)
+	for _, tc := range testCases {
+		res, err := b.Translate(context.Background(), tc.text, tc.source, tc.target, "")
+		if err != nil {
+			t.Error(err)
+		}
+		t.Log(res.PrettyPrint())
+	}
+}
+
+func TestDetect(t *testing.T) {
+	testCases := []struct {
+		text, lang string
+	}{
+		{"ciao, come stai?", "it"},
+		{"Hello World!", "en"},
+	}
+	b := newTestBackend(t)
+	for _, tc := range testCases {
+		lang, err := b.Detect(context.Background(), tc.text)
+		if err != nil {
+			t.Error(err)
+		}
+		if lang != tc.lang {
+			t.Errorf("expected %s, got %s", tc.lang, lang)
+		}
+	}
+}
+
+func TestLanguages(t *testing.T) {
+	b := newTestBackend(t)
+	languages, err := b.Languages(context.Background())
+	if err != nil {
+		t.Error(err)
+	}
+	for _, l := range languages {
+		if l.Code == "en" {
+			return
+		}
+	}
+	t.Error("english is not listed among the supported languages")
+}
+
+func TestCanceled(t *testing.T) {
+	b := New("", time.Second, "http://127.0.0.1:1")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := b.Translate(ctx, "ciao", "it", "en", ""); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected a canceled translation, got %v", err)
+	}
+	if _, err := b.Detect(ctx, "ciao"); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected a canceled detection, got %v", err)
+	}
+}
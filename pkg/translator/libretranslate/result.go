@@ -0,0 +1,13 @@
+package libretranslate
+
+import (
+	"github.com/fedeztk/got/pkg/translator/utils"
+)
+
+func (r Response) Result() utils.Result {
+	return utils.Result{
+		Translation:  r.TranslatedText,
+		Alternatives: r.Alternatives,
+		Detected:     r.Detected.Language,
+	}
+}
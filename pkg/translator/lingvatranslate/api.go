@@ -1,11 +1,13 @@
 package lingvatranslate
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/fedeztk/got/pkg/translator/utils"
 )
@@ -13,7 +15,8 @@ import (
 type Response struct {
 	Translation string `json:"translation,omitempty"`
 	Info        struct {
-		Pronunciation struct {
+		DetectedSource string `json:"detectedSource,omitempty"`
+		Pronunciation  struct {
 			Query string `json:"query,omitempty"`
 		} `json:"pronunciation,omitempty"`
 		Definitions []struct {
@@ -38,25 +41,29 @@ type Response struct {
 	} `json:"info,omitempty"`
 }
 
+// DefaultInstance is used when no instance is configured
+const DefaultInstance = "https://lingva.ml"
+
 type LingvaTranslate struct {
-	languages map[string]string
 	client    http.Client
-	baseURL   string
+	instances *utils.Instances
 }
 
-func New() LingvaTranslate {
+// New returns a lingvatranslate backend that uses the given instances,
+// or DefaultInstance if none is given.
+// A zero timeout means no timeout
+func New(timeout time.Duration, instances ...string) LingvaTranslate {
 	return LingvaTranslate{
-		languages: utils.GetAllLanguages(),
-		client:    http.Client{},
-		baseURL:   "https://lingva.ml/api/v1/",
+		client:    http.Client{Timeout: timeout},
+		instances: utils.NewInstances(instances, DefaultInstance),
 	}
 }
 
-func (b LingvaTranslate) Translate(text, source, target, engine string) (utils.BackendResponse, error) {
+func (b LingvaTranslate) Translate(ctx context.Context, text, source, target, engine string) (utils.BackendResponse, error) {
 	r := Response{}
 
 	if text == "" {
-		return r, nil
+		return r.Result(), nil
 	}
 	if source == "" {
 		source = "auto"
@@ -65,38 +72,77 @@ func (b LingvaTranslate) Translate(text, source, target, engine string) (utils.B
 		target = "en"
 	}
 
-	if _, ok := b.languages[source]; !ok {
-		return r, errors.New("source language not supported")
+	// languages are validated by the server, see Languages for the supported ones
+	res, err := b.instances.Do(&b.client, func(baseURL string) (*http.Request, error) {
+		var translateURL = baseURL + "/api/v1/" + source + "/" + target + "/" + url.QueryEscape(text)
+		return http.NewRequestWithContext(ctx, "GET", translateURL, nil)
+	})
+	if err != nil {
+		return r.Result(), err
 	}
-	if _, ok := b.languages[target]; !ok {
-		return r, errors.New("target language not supported")
+	defer res.Body.Close()
+
+	if res.StatusCode != 200 {
+		return r.Result(), errors.New("Unable to translate! Status code received from server: " + res.Status)
 	}
 
-	var translateURL = b.baseURL + "/" + source + "/" + target + "/" + url.QueryEscape(text)
-	req, err := http.NewRequest("GET", translateURL, nil)
+	err = json.NewDecoder(res.Body).Decode(&r)
 	if err != nil {
-		return r, err
+		return r.Result(), err
 	}
 
-	res, err := b.client.Do(req)
+	return r.Result(), nil
+}
+
+// Detect returns the code of the language the text is written in, lingva has
+// no dedicated endpoint so the language detected by a translation is used
+func (b LingvaTranslate) Detect(ctx context.Context, text string) (string, error) {
+	if text == "" {
+		return "", nil
+	}
+
+	r, err := b.Translate(ctx, text, "auto", "en", "")
 	if err != nil {
-		return r, err
+		return "", err
+	}
+	if detected := r.DetectedLanguage(); detected != "" {
+		return detected, nil
+	}
+	return "", errors.New("Unable to detect language! No language received from server")
+}
+
+// Languages returns the languages supported by the instance
+func (b LingvaTranslate) Languages(ctx context.Context) ([]utils.Language, error) {
+	var r struct {
+		Languages []utils.Language `json:"languages"`
+	}
+
+	res, err := b.instances.Do(&b.client, func(baseURL string) (*http.Request, error) {
+		return http.NewRequestWithContext(ctx, "GET", baseURL+"/api/v1/languages", nil)
+	})
+	if err != nil {
+		return nil, err
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode != 200 {
-		return r, errors.New("Unable to translate! Status code received from server: " + res.Status)
+		return nil, errors.New("Unable to get languages! Status code received from server: " + res.Status)
 	}
 
 	err = json.NewDecoder(res.Body).Decode(&r)
 	if err != nil {
-		return r, err
+		return nil, err
 	}
 
-	return r, nil
+	for i := range r.Languages {
+		if r.Languages[i].Code == utils.AutoDetect.Code {
+			r.Languages[i].SourceOnly = true
+		}
+	}
+	return r.Languages, nil
 }
 
-func (b LingvaTranslate) TextToSpeech(text, lang string) ([]byte, error) {
+func (b LingvaTranslate) TextToSpeech(ctx context.Context, text, lang string) ([]byte, error) {
 	type audioResponse struct {
 		Audio []byte `json:"audio,omitempty"`
 	}
@@ -110,17 +156,10 @@ func (b LingvaTranslate) TextToSpeech(text, lang string) ([]byte, error) {
 		lang = "en"
 	}
 
-	if _, ok := b.languages[lang]; !ok {
-		return r, errors.New("language not supported")
-	}
-
-	var ttsURL = b.baseURL + "/audio/" + lang + "/" + url.QueryEscape(text)
-	req, err := http.NewRequest("GET", ttsURL, nil)
-	if err != nil {
-		return r, err
-	}
-
-	res, err := b.client.Do(req)
+	res, err := b.instances.Do(&b.client, func(baseURL string) (*http.Request, error) {
+		var ttsURL = baseURL + "/api/v1/audio/" + lang + "/" + url.QueryEscape(text)
+		return http.NewRequestWithContext(ctx, "GET", ttsURL, nil)
+	})
 	if err != nil {
 		return r, err
 	}
@@ -1,8 +1,10 @@
 package lingvatranslate
 
 import (
+	"context"
 	"encoding/json"
 	"testing"
+	"time"
 )
 
 func TestTranslation(t *testing.T) {
@@ -14,9 +16,9 @@ func TestTranslation(t *testing.T) {
 		{"corsa", "it", "en"},
 		{"exit", "en", "it"},
 	}
-	b := New()
+	b := New(10 * time.Second)
 	for _, tc := range testCases {
-		res, err := b.Translate(tc.text, tc.source, tc.target, "")
+		res, err := b.Translate(context.Background(), tc.text, tc.source, tc.target, "")
 		if err != nil {
 			t.Error(err)
 		}
@@ -38,9 +40,9 @@ func TestPrettyPrint(t *testing.T) {
 		{"exit", "en", "it"},
 		{"the", "en", "it"},
 	}
-	b := New()
+	b := New(10 * time.Second)
 	for _, tc := range testCases {
-		res, err := b.Translate(tc.text, tc.source, tc.target, "")
+		res, err := b.Translate(context.Background(), tc.text, tc.source, tc.target, "")
 		if err != nil {
 			t.Error(err)
 		}
@@ -56,11 +58,25 @@ func TestTTS(t *testing.T) {
 		{"ciao", "it"},
 		{"Hello World!", "en"},
 	}
-	b := New()
+	b := New(10 * time.Second)
 	for _, tc := range testCases {
-		_, err := b.TextToSpeech(tc.text, tc.lang)
+		_, err := b.TextToSpeech(context.Background(), tc.text, tc.lang)
 		if err != nil {
 			t.Error(err)
 		}
 	}
 }
+
+func TestLanguages(t *testing.T) {
+	b := New(10 * time.Second)
+	languages, err := b.Languages(context.Background())
+	if err != nil {
+		t.Error(err)
+	}
+	for _, l := range languages {
+		if l.Code == "en" {
+			return
+		}
+	}
+	t.Error("english is not listed among the supported languages")
+}
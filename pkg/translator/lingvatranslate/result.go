@@ -0,0 +1,44 @@
+package lingvatranslate
+
+import (
+	"strings"
+
+	"github.com/fedeztk/got/pkg/translator/utils"
+)
+
+func (r Response) Result() utils.Result {
+	result := utils.Result{
+		Translation:   r.Translation,
+		Pronunciation: r.Info.Pronunciation.Query,
+		Detected:      r.Info.DetectedSource,
+	}
+	for _, def := range r.Info.Definitions {
+		group := utils.DefinitionGroup{PartOfSpeech: def.Type}
+		for _, list := range def.List {
+			group.Definitions = append(group.Definitions, utils.Definition{
+				Definition: list.Definition,
+				Example:    list.Example,
+				Synonyms:   list.Synonyms,
+				Field:      list.Field,
+			})
+		}
+		result.Definitions = append(result.Definitions, group)
+	}
+	for _, example := range r.Info.Examples {
+		sanitizedExample := strings.ReplaceAll(example, "<b>", "")
+		sanitizedExample = strings.ReplaceAll(sanitizedExample, "</b>", "")
+		result.Examples = append(result.Examples, sanitizedExample)
+	}
+	for _, extraTranslation := range r.Info.ExtraTranslations {
+		group := utils.TranslationGroup{PartOfSpeech: extraTranslation.Type}
+		for _, list := range extraTranslation.List {
+			group.Translations = append(group.Translations, utils.ExtraTranslation{
+				Word:      list.Word,
+				Meanings:  list.Meanings,
+				Frequency: list.Frequency,
+			})
+		}
+		result.ExtraTranslations = append(result.ExtraTranslations, group)
+	}
+	return result
+}
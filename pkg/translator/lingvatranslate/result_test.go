@@ -0,0 +1,38 @@
+package lingvatranslate
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestResult(t *testing.T) {
+	var r Response
+	err := json.Unmarshal([]byte(`{
+		"translation": "lid",
+		"info": {
+			"detectedSource": "it",
+			"pronunciation": {"query": "koˈpɛrkjo"},
+			"definitions": [{"type": "noun", "list": [{"definition": "a cover", "synonyms": ["cap", "top"]}]}],
+			"examples": ["the <b>lid</b> of the pot"],
+			"extraTranslations": [{"type": "noun", "list": [{"word": "lid", "meanings": ["coperchio"], "frequency": 3}]}]
+		}
+	}`), &r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := r.Result()
+	if result.Translation != "lid" || result.Detected != "it" || result.Pronunciation != "koˈpɛrkjo" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	if len(result.Examples) != 1 || strings.Contains(result.Examples[0], "<b>") {
+		t.Errorf("examples should be sanitized, got %q", result.Examples)
+	}
+	if len(result.Definitions) != 1 || len(result.Definitions[0].Definitions[0].Synonyms) != 2 {
+		t.Errorf("unexpected definitions: %+v", result.Definitions)
+	}
+	if len(result.ExtraTranslations) != 1 || result.ExtraTranslations[0].Translations[0].Frequency != 3 {
+		t.Errorf("unexpected extra translations: %+v", result.ExtraTranslations)
+	}
+}
@@ -0,0 +1,68 @@
+package translator
+
+import (
+	"regexp"
+	"strconv"
+)
+
+// placeholder matches the placeholders of Placeholders, engines sometimes add
+// spaces inside the brackets
+var placeholder = regexp.MustCompile(`\[\[\s*(\d+)\s*\]\]`)
+
+// Placeholders replaces the pieces of a text that must not be translated with
+// numbered placeholders like [[0]], which the engines leave untouched, and
+// puts values back in their place once translated
+type Placeholders struct {
+	values []string
+}
+
+// Add returns the placeholder standing for value, the same value always gets
+// the same placeholder
+func (p *Placeholders) Add(value string) string {
+	i := 0
+	for i < len(p.values) && p.values[i] != value {
+		i++
+	}
+	if i == len(p.values) {
+		p.values = append(p.values, value)
+	}
+	return "[[" + strconv.Itoa(i) + "]]"
+}
+
+// Len returns the number of distinct values replaced
+func (p *Placeholders) Len() int {
+	return len(p.values)
+}
+
+// Restore replaces the placeholders in text with their values, unknown
+// placeholders are left as they are
+func (p *Placeholders) Restore(text string) string {
+	return placeholder.ReplaceAllStringFunc(text, func(match string) string {
+		i, err := strconv.Atoi(placeholder.FindStringSubmatch(match)[1])
+		if err != nil || i >= len(p.values) {
+			return match
+		}
+		return p.values[i]
+	})
+}
+
+// Protect replaces every match of re in text with a placeholder
+func (p *Placeholders) Protect(text string, re *regexp.Regexp) string {
+	return re.ReplaceAllStringFunc(text, p.Add)
+}
+
+// Split cuts text at every placeholder standing for value, text is returned
+// whole if value has no placeholder
+func (p *Placeholders) Split(text, value string) []string {
+	parts := []string{}
+	start := 0
+	for _, match := range placeholder.FindAllStringSubmatchIndex(text, -1) {
+		i, err := strconv.Atoi(text[match[2]:match[3]])
+		if err != nil || i >= len(p.values) || p.values[i] != value {
+			continue
+		}
+		parts = append(parts, text[start:match[0]])
+		start = match[1]
+	}
+	return append(parts, text[start:])
+}
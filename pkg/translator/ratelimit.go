@@ -0,0 +1,78 @@
+package translator
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/fedeztk/got/pkg/translator/utils"
+)
+
+// rateLimited is a backend whose requests start at regular intervals
+type rateLimited struct {
+	Backend
+	interval time.Duration
+	mu       sync.Mutex
+	next     time.Time // when the next request may start
+}
+
+// RateLimit returns b limited to perSecond requests a second, b itself if
+// perSecond is not positive
+func RateLimit(b Backend, perSecond float64) Backend {
+	if perSecond <= 0 {
+		return b
+	}
+	return &rateLimited{Backend: b, interval: time.Duration(float64(time.Second) / perSecond)}
+}
+
+// wait blocks until a request may start or ctx is done
+func (r *rateLimited) wait(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	now := time.Now()
+	if r.next.Before(now) {
+		r.next = now
+	}
+	start := r.next
+	r.next = r.next.Add(r.interval)
+	r.mu.Unlock()
+
+	timer := time.NewTimer(time.Until(start))
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (r *rateLimited) Translate(ctx context.Context, text, source, target, engine string) (utils.BackendResponse, error) {
+	if err := r.wait(ctx); err != nil {
+		return nil, err
+	}
+	return r.Backend.Translate(ctx, text, source, target, engine)
+}
+
+func (r *rateLimited) TextToSpeech(ctx context.Context, text, language string) ([]byte, error) {
+	if err := r.wait(ctx); err != nil {
+		return nil, err
+	}
+	return r.Backend.TextToSpeech(ctx, text, language)
+}
+
+func (r *rateLimited) Detect(ctx context.Context, text string) (string, error) {
+	if err := r.wait(ctx); err != nil {
+		return "", err
+	}
+	return r.Backend.Detect(ctx, text)
+}
+
+func (r *rateLimited) Languages(ctx context.Context) ([]utils.Language, error) {
+	if err := r.wait(ctx); err != nil {
+		return nil, err
+	}
+	return r.Backend.Languages(ctx)
+}
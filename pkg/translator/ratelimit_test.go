@@ -0,0 +1,27 @@
+package translator
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestRateLimit(t *testing.T) {
+	b := &recordingBackend{}
+	if RateLimit(b, 0) != Backend(b) {
+		t.Error("expected no limit when the rate is 0")
+	}
+
+	limited := RateLimit(b, 20)
+	start := time.Now()
+	TranslateTexts(context.Background(), limited, []string{"a", "b", "c", "d"}, "en", "it", "google", 4, nil)
+	if elapsed := time.Since(start); elapsed < 150*time.Millisecond {
+		t.Errorf("4 requests at 20 a second took %v, want at least 150ms", elapsed)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := limited.Translate(ctx, "e", "en", "it", "google"); err == nil {
+		t.Error("expected the error of the canceled context")
+	}
+}
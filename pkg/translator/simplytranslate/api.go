@@ -1,11 +1,13 @@
 package simplytranslate
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"io/ioutil"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/fedeztk/got/pkg/translator/utils"
 )
@@ -25,27 +27,35 @@ type Response struct {
 	} `json:"translations,omitempty"`
 }
 
+// DefaultInstance is used when no instance is configured
+const DefaultInstance = "https://simplytranslate.org"
+
+// Engines are the supported engines, the first one is the default and the
+// only one with text to speech. Deepl is left out as it is not working yet
+var Engines = []string{"google", "iciba", "reverso", "libre"}
+
 type SimplyTranslate struct {
 	languages map[string]string
-	engines   []string
 	client    http.Client
-	baseURL   string
+	instances *utils.Instances
 }
 
-func New() SimplyTranslate {
+// New returns a simplytranslate backend that uses the given instances,
+// or DefaultInstance if none is given.
+// A zero timeout means no timeout
+func New(timeout time.Duration, instances ...string) SimplyTranslate {
 	return SimplyTranslate{
 		languages: utils.GetAllLanguages(),
-		engines:   []string{"google", "deepl", "libre", "iciba", "reverso"},
-		client:    http.Client{},
-		baseURL:   "https://simplytranslate.org/api",
+		client:    http.Client{Timeout: timeout},
+		instances: utils.NewInstances(instances, DefaultInstance),
 	}
 }
 
-func (b SimplyTranslate) Translate(text, source, target, engine string) (utils.BackendResponse, error) {
+func (b SimplyTranslate) Translate(ctx context.Context, text, source, target, engine string) (utils.BackendResponse, error) {
 	r := Response{}
 
 	if text == "" {
-		return r, nil
+		return r.Result(), nil
 	}
 	if source == "" {
 		source = "auto"
@@ -54,15 +64,15 @@ func (b SimplyTranslate) Translate(text, source, target, engine string) (utils.B
 		target = "en"
 	}
 
-	if _, ok := b.languages[source]; !ok {
-		return r, errors.New("source language not supported")
+	if _, ok := b.languages[source]; !ok && source != "auto" {
+		return r.Result(), errors.New("source language not supported")
 	}
 	if _, ok := b.languages[target]; !ok {
-		return r, errors.New("target language not supported")
+		return r.Result(), errors.New("target language not supported")
 	}
 
 	checkEngine := func(engine string) bool {
-		for _, e := range b.engines {
+		for _, e := range Engines {
 			if e == engine {
 				return true
 			}
@@ -70,43 +80,53 @@ func (b SimplyTranslate) Translate(text, source, target, engine string) (utils.B
 		return false
 	}
 	if !checkEngine(engine) {
-		return r, errors.New("engine not supported, please use one of the following: " + strings.Join(b.engines, ", "))
-	}
-
-	var translateURL = b.baseURL + "/translate/?engine="
-	req, err := http.NewRequest("GET", translateURL+engine, nil)
-	if err != nil {
-		return r, err
+		return r.Result(), errors.New("engine not supported, please use one of the following: " + strings.Join(Engines, ", "))
 	}
 
-	query := req.URL.Query()
-	query.Add("from", source)
-	query.Add("to", target)
-	query.Add("text", text)
-	req.URL.RawQuery = query.Encode()
+	res, err := b.instances.Do(&b.client, func(baseURL string) (*http.Request, error) {
+		var translateURL = baseURL + "/api/translate/?engine="
+		req, err := http.NewRequestWithContext(ctx, "GET", translateURL+engine, nil)
+		if err != nil {
+			return nil, err
+		}
 
-	res, err := b.client.Do(req)
+		query := req.URL.Query()
+		query.Add("from", source)
+		query.Add("to", target)
+		query.Add("text", text)
+		req.URL.RawQuery = query.Encode()
+		return req, nil
+	})
 	if err != nil {
-		return r, err
+		return r.Result(), err
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode != 200 {
-		return r, errors.New("Unable to translate! Status code received from server: " + res.Status)
+		return r.Result(), errors.New("Unable to translate! Status code received from server: " + res.Status)
 	}
 
 	err = json.NewDecoder(res.Body).Decode(&r)
 	if err != nil {
-		return r, err
+		return r.Result(), err
 	}
 
-	return r, nil
+	return r.Result(), nil
+}
+
+func (b SimplyTranslate) Detect(ctx context.Context, text string) (string, error) {
+	return "", errors.New("language detection is not supported by simplytranslate")
+}
+
+// Languages returns the static list of languages, simplytranslate can't list them
+func (b SimplyTranslate) Languages(ctx context.Context) ([]utils.Language, error) {
+	return utils.GetLanguageList(), nil
 }
 
-func (b SimplyTranslate) TextToSpeech(text, lang string) ([]byte, error) {
+func (b SimplyTranslate) TextToSpeech(ctx context.Context, text, lang string) ([]byte, error) {
 	var r []byte
 	// only google tts is supported
-	engine := b.engines[0]
+	engine := Engines[0]
 
 	if text == "" {
 		return r, nil
@@ -119,18 +139,19 @@ func (b SimplyTranslate) TextToSpeech(text, lang string) ([]byte, error) {
 		return r, errors.New("language not supported")
 	}
 
-	var ttsURL = b.baseURL + "/api/tts/?engine="
-	req, err := http.NewRequest("GET", ttsURL+engine, nil)
-	if err != nil {
-		return r, err
-	}
-
-	query := req.URL.Query()
-	query.Add("lang", lang)
-	query.Add("text", text)
-	req.URL.RawQuery = query.Encode()
+	res, err := b.instances.Do(&b.client, func(baseURL string) (*http.Request, error) {
+		var ttsURL = baseURL + "/api/tts/?engine="
+		req, err := http.NewRequestWithContext(ctx, "GET", ttsURL+engine, nil)
+		if err != nil {
+			return nil, err
+		}
 
-	res, err := b.client.Do(req)
+		query := req.URL.Query()
+		query.Add("lang", lang)
+		query.Add("text", text)
+		req.URL.RawQuery = query.Encode()
+		return req, nil
+	})
 	if err != nil {
 		return r, err
 	}
@@ -3,8 +3,10 @@ package simplytranslate
 // NOTE: test are commented out since simplytranslate is nout up anymore
 
 // import (
+// 	"context"
 // 	"encoding/json"
 // 	"testing"
+// 	"time"
 // )
 
 // func TestTranslation(t *testing.T) {
@@ -16,10 +18,10 @@ package simplytranslate
 // 		{"corsa", "it", "en"},
 // 		{"exit", "en", "it"},
 // 	}
-// 	b := New()
+// 	b := New(10 * time.Second)
 // 	for _, tc := range testCases {
 // 		for _, engine := range b.engines {
-// 			res, err := b.Translate(tc.text, tc.source, tc.target, engine)
+// 			res, err := b.Translate(context.Background(), tc.text, tc.source, tc.target, engine)
 // 			if err != nil {
 // 				t.Error(err)
 // 			}
@@ -42,10 +44,10 @@ package simplytranslate
 // 		{"exit", "en", "it"},
 // 		{"the", "en", "it"},
 // 	}
-// 	b := New()
+// 	b := New(10 * time.Second)
 // 	for _, tc := range testCases {
 // 		for _, engine := range b.engines {
-// 			res, err := b.Translate(tc.text, tc.source, tc.target, engine)
+// 			res, err := b.Translate(context.Background(), tc.text, tc.source, tc.target, engine)
 // 			if err != nil {
 // 				t.Error(err)
 // 			}
@@ -62,9 +64,9 @@ package simplytranslate
 // 		{"ciao", "it"},
 // 		{"Hello World!", "en"},
 // 	}
-// 	b := New()
+// 	b := New(10 * time.Second)
 // 	for _, tc := range testCases {
-// 		_, err := b.TextToSpeech(tc.text, tc.lang)
+// 		_, err := b.TextToSpeech(context.Background(), tc.text, tc.lang)
 // 		if err != nil {
 // 			t.Error(err)
 // 		}
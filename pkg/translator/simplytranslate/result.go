@@ -0,0 +1,59 @@
+package simplytranslate
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/fedeztk/got/pkg/translator/utils"
+)
+
+func (r Response) Result() utils.Result {
+	result := utils.Result{Translation: r.TranslatedText}
+	for _, category := range sortedKeys(r.DefinitionsByCategory) {
+		group := utils.DefinitionGroup{PartOfSpeech: getPartOfSpeechOrUndefined(category)}
+		for _, def := range r.DefinitionsByCategory[category] {
+			definition := utils.Definition{
+				Definition: def.Definition,
+				Example:    def.UseInSentence,
+				Field:      def.Dictionary,
+				Informal:   def.Informal,
+			}
+			for _, key := range sortedKeys(def.Synonyms) {
+				definition.Synonyms = append(definition.Synonyms, def.Synonyms[key]...)
+			}
+			group.Definitions = append(group.Definitions, definition)
+		}
+		result.Definitions = append(result.Definitions, group)
+	}
+	for _, category := range sortedKeys(r.SingleTranslation) {
+		group := utils.TranslationGroup{PartOfSpeech: getPartOfSpeechOrUndefined(category)}
+		for _, singleWord := range sortedKeys(r.SingleTranslation[category]) {
+			translations := r.SingleTranslation[category][singleWord]
+			frequency, _ := strconv.Atoi(translations.Frequency)
+			group.Translations = append(group.Translations, utils.ExtraTranslation{
+				Word:      singleWord,
+				Meanings:  translations.Words,
+				Frequency: frequency,
+			})
+		}
+		result.ExtraTranslations = append(result.ExtraTranslations, group)
+	}
+	return result
+}
+
+func getPartOfSpeechOrUndefined(s string) string {
+	if s == "" || s == "null" {
+		return "undefined"
+	}
+	return s
+}
+
+// sortedKeys keeps the output stable, since the response is made of maps
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,32 @@
+package simplytranslate
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestResult(t *testing.T) {
+	var r Response
+	err := json.Unmarshal([]byte(`{
+		"translated-text": "lid",
+		"definitions": {"noun": [{"definition": "a cover", "use-in-sentence": "put the lid on", "synonyms": {"": ["cap"]}}]},
+		"translations": {"null": {"lid": {"words": ["coperchio"], "frequency": "2"}}}
+	}`), &r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := r.Result()
+	if result.Translation != "lid" || len(result.Definitions) != 1 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if def := result.Definitions[0].Definitions[0]; def.Example != "put the lid on" || len(def.Synonyms) != 1 {
+		t.Errorf("unexpected definition: %+v", def)
+	}
+	if len(result.ExtraTranslations) != 1 || result.ExtraTranslations[0].PartOfSpeech != "undefined" {
+		t.Errorf("unexpected extra translations: %+v", result.ExtraTranslations)
+	}
+	if result.ExtraTranslations[0].Translations[0].Frequency != 2 {
+		t.Errorf("frequency should be parsed, got %+v", result.ExtraTranslations[0].Translations[0])
+	}
+}
@@ -0,0 +1,81 @@
+package translator
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/fedeztk/got/pkg/translator/utils"
+)
+
+// MaxWorkers is the default number of requests sent at the same time when
+// translating to several target languages
+const MaxWorkers = 4
+
+// TargetResult is the translation of a text to one of several target languages
+type TargetResult struct {
+	Target   string
+	Response utils.BackendResponse
+	Err      error
+}
+
+// ParseTargets returns the target languages of a comma separated list, e.g. it,de,fr
+func ParseTargets(targets string) []string {
+	parsed := []string{}
+	for _, t := range strings.Split(targets, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			parsed = append(parsed, t)
+		}
+	}
+	return parsed
+}
+
+// TranslateTargets translates text to every target language sending at most
+// workers requests at the same time, the results follow the order of targets
+func TranslateTargets(ctx context.Context, b Backend, text, source string, targets []string, engine string, workers int) []TargetResult {
+	results := make([]TargetResult, len(targets))
+	parallel(len(targets), workers, func(i int) {
+		r, err := TranslateText(ctx, b, text, source, targets[i], engine)
+		results[i] = TargetResult{Target: targets[i], Response: r, Err: err}
+	})
+	return results
+}
+
+// parallel calls job for every index up to n, running at most workers jobs at the same time
+func parallel(n, workers int, job func(i int)) {
+	if workers < 1 {
+		workers = 1
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers && w < n; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				job(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+}
+
+// PrettyPrintTargets renders the results in stacked sections, one per target language
+func PrettyPrintTargets(results []TargetResult) string {
+	sections := make([]string, 0, len(results))
+	for _, r := range results {
+		section := utils.Section.Render("→ "+r.Target) + "\n\n"
+		if r.Err != nil {
+			section += utils.IndentOne.Render(r.Err.Error()) + "\n"
+		} else {
+			section += r.Response.PrettyPrint()
+		}
+		sections = append(sections, section)
+	}
+	return strings.Join(sections, "\n")
+}
@@ -0,0 +1,71 @@
+package translator
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fedeztk/got/pkg/translator/utils"
+)
+
+// slowBackend translates after a delay, tracking the highest number of concurrent requests
+type slowBackend struct {
+	Backend
+	mu              sync.Mutex
+	running, peak   int
+	failingLanguage string
+}
+
+func (b *slowBackend) Translate(ctx context.Context, text, source, target, engine string) (utils.BackendResponse, error) {
+	b.mu.Lock()
+	b.running++
+	if b.running > b.peak {
+		b.peak = b.running
+	}
+	b.mu.Unlock()
+
+	time.Sleep(10 * time.Millisecond)
+
+	b.mu.Lock()
+	b.running--
+	b.mu.Unlock()
+	if target == b.failingLanguage {
+		return nil, errors.New("unsupported language")
+	}
+	return utils.Result{Translation: target + ": " + text}, nil
+}
+
+func TestParseTargets(t *testing.T) {
+	if got := ParseTargets(" it, de,,fr "); !reflect.DeepEqual(got, []string{"it", "de", "fr"}) {
+		t.Errorf("unexpected targets %v", got)
+	}
+}
+
+func TestTranslateTargets(t *testing.T) {
+	b := &slowBackend{failingLanguage: "xx"}
+	targets := []string{"it", "de", "xx", "fr", "es", "pt"}
+
+	results := TranslateTargets(context.Background(), b, "hello", "en", targets, "", 2)
+
+	if b.peak > 2 {
+		t.Errorf("expected at most 2 concurrent requests, got %d", b.peak)
+	}
+	for i, r := range results {
+		if r.Target != targets[i] {
+			t.Errorf("result %d is for %s instead of %s", i, r.Target, targets[i])
+			continue
+		}
+		if r.Target == "xx" {
+			if r.Err == nil {
+				t.Error("expected an error for the unsupported language")
+			}
+			continue
+		}
+		if r.Err != nil || r.Response.ShortTranslatedText() != r.Target+": hello" {
+			t.Errorf("unexpected result for %s: %v %v", r.Target, r.Response, r.Err)
+		}
+	}
+}
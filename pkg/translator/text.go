@@ -0,0 +1,238 @@
+package translator
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/fedeztk/got/pkg/translator/utils"
+)
+
+// MaxChunkLength is the length in bytes of the longest text sent in a single
+// request. Lingva puts the text in the url path, once escaped it must stay
+// well below the url length limits of servers and proxies
+const MaxChunkLength = 1000
+
+var (
+	paragraphBreak = regexp.MustCompile(`\n[ \t]*\n\s*`)
+	sentenceEnd    = regexp.MustCompile(`[.!?;。！？](\s+)`)
+	spaces         = regexp.MustCompile(`\s+`)
+)
+
+// Chunk is a piece of text followed by the whitespace separating it from the next one
+type Chunk struct {
+	Text string
+	Sep  string
+}
+
+// SplitText splits text in chunks of at most max bytes: paragraphs are always
+// split, longer ones are cut at sentence ends, then at spaces and as a last
+// resort anywhere. Concatenating text and separators of the chunks gives back text
+func SplitText(text string, max int) []Chunk {
+	chunks := []Chunk{}
+	for _, paragraph := range splitAt(text, paragraphBreak) {
+		chunks = append(chunks, fit(paragraph, max, sentenceEnd, spaces)...)
+	}
+	return chunks
+}
+
+// splitAt cuts text at every match of re, the separator is the last submatch of
+// re if it has one, the whole match otherwise
+func splitAt(text string, re *regexp.Regexp) []Chunk {
+	chunks := []Chunk{}
+	start := 0
+	for _, match := range re.FindAllStringSubmatchIndex(text, -1) {
+		sepStart, sepEnd := match[0], match[1]
+		if len(match) > 2 {
+			sepStart, sepEnd = match[len(match)-2], match[len(match)-1]
+		}
+		chunks = append(chunks, Chunk{Text: text[start:sepStart], Sep: text[sepStart:sepEnd]})
+		start = sepEnd
+	}
+	if start < len(text) || len(chunks) == 0 {
+		chunks = append(chunks, Chunk{Text: text[start:]})
+	}
+	return chunks
+}
+
+// fit splits c at the first of the separators with chunks no longer than max,
+// grouping consecutive pieces as long as they fit
+func fit(c Chunk, max int, separators ...*regexp.Regexp) []Chunk {
+	if len(c.Text) <= max {
+		return []Chunk{c}
+	}
+	if len(separators) == 0 {
+		return cut(c, max)
+	}
+
+	pieces := splitAt(c.Text, separators[0])
+	pieces[len(pieces)-1].Sep += c.Sep
+
+	chunks := []Chunk{}
+	var current *Chunk
+	for _, p := range pieces {
+		if len(p.Text) > max {
+			chunks = append(chunks, fit(p, max, separators[1:]...)...)
+			current = nil
+			continue
+		}
+		if current != nil && len(current.Text)+len(current.Sep)+len(p.Text) <= max {
+			current.Text += current.Sep + p.Text
+			current.Sep = p.Sep
+			continue
+		}
+		chunks = append(chunks, p)
+		current = &chunks[len(chunks)-1]
+	}
+	return chunks
+}
+
+// cut splits c every max bytes, without breaking runes
+func cut(c Chunk, max int) []Chunk {
+	chunks := []Chunk{}
+	text := c.Text
+	for len(text) > max {
+		end := max
+		for end > 0 && !utf8.RuneStart(text[end]) {
+			end--
+		}
+		if end == 0 { // max is shorter than a single rune
+			_, end = utf8.DecodeRuneInString(text)
+		}
+		chunks = append(chunks, Chunk{Text: text[:end]})
+		text = text[end:]
+	}
+	return append(chunks, Chunk{Text: text, Sep: c.Sep})
+}
+
+// TranslateText translates text of any length, sending it in chunks of at most
+// MaxChunkLength bytes. When text fits in a single chunk the response of the
+// backend is returned as is, otherwise the translated chunks are joined in
+// order keeping the original paragraph breaks
+func TranslateText(ctx context.Context, b Backend, text, source, target, engine string) (utils.BackendResponse, error) {
+	chunks := SplitText(text, MaxChunkLength)
+	if len(chunks) == 1 {
+		return b.Translate(ctx, text, source, target, engine)
+	}
+
+	joined := utils.Result{}
+	builder := strings.Builder{}
+	for _, c := range chunks {
+		if strings.TrimSpace(c.Text) != "" {
+			r, err := b.Translate(ctx, c.Text, source, target, engine)
+			if err != nil {
+				return nil, err
+			}
+			builder.WriteString(r.ShortTranslatedText())
+			if joined.Detected == "" {
+				joined.Detected = r.DetectedLanguage()
+			}
+		} else {
+			builder.WriteString(c.Text)
+		}
+		builder.WriteString(c.Sep)
+	}
+	joined.Translation = builder.String()
+	return joined, nil
+}
+
+// FormatPlaceholders matches the placeholders of the common format strings,
+// which must reach the translation unchanged: printf verbs like %s or %1$d,
+// python named ones like %(name)s and braces like {0}, {name}, %{name}, ${name} or {{ name }}
+var FormatPlaceholders = regexp.MustCompile(strings.Join([]string{
+	`%\([^)\s]+\)[-+#0]*\d*(?:\.\d+)?[a-zA-Z]`,
+	`%(?:\d+\$)?[-+#0']*(?:\*|\d+)?(?:\.(?:\*|\d+))?(?:hh|h|ll|l|L|q|j|z|t)?[diouxXeEfFgGaAcspn%]`,
+	`[%$]?\{\{[^{}]*\}\}`,
+	`[%$]?\{[^{}\s]*\}`,
+}, "|"))
+
+// TextResult is the translation of one of several texts
+type TextResult struct {
+	Text string
+	Err  error
+}
+
+// TranslateTexts translates every text to target sending at most workers
+// requests at the same time, the results follow the order of texts. The parts
+// of the texts matching protect, if not nil, are left as they are, and so is
+// the whitespace around the texts
+func TranslateTexts(ctx context.Context, b Backend, texts []string, source, target, engine string, workers int, protect *regexp.Regexp) []TextResult {
+	results := make([]TextResult, len(texts))
+	TranslateEach(ctx, b, texts, source, target, engine, workers, protect, func(i int, r TextResult) {
+		results[i] = r
+	})
+	return results
+}
+
+// TranslateEach is TranslateTexts handing every result to done as soon as it
+// is ready, done is called by the workers and must be safe for concurrent use.
+// Once ctx is done the texts not yet sent are skipped
+func TranslateEach(ctx context.Context, b Backend, texts []string, source, target, engine string, workers int, protect *regexp.Regexp, done func(i int, r TextResult)) {
+	parallel(len(texts), workers, func(i int) {
+		if err := ctx.Err(); err != nil {
+			done(i, TextResult{Err: err})
+			return
+		}
+		text, err := translateTrimmed(ctx, b, texts[i], source, target, engine, protect)
+		done(i, TextResult{Text: text, Err: err})
+	})
+}
+
+// translateTrimmed translates text without its surrounding whitespace, which
+// the engines drop, and the matches of protect
+func translateTrimmed(ctx context.Context, b Backend, text, source, target, engine string, protect *regexp.Regexp) (string, error) {
+	trimmed := strings.TrimSpace(text)
+	if trimmed == "" {
+		return text, nil
+	}
+	start := strings.Index(text, trimmed)
+	leading, trailing := text[:start], text[start+len(trimmed):]
+
+	p := &Placeholders{}
+	if protect != nil {
+		trimmed = p.Protect(trimmed, protect)
+	}
+	r, err := TranslateText(ctx, b, trimmed, source, target, engine)
+	if err != nil {
+		return "", err
+	}
+	return leading + p.Restore(strings.TrimSpace(r.ShortTranslatedText())) + trailing, nil
+}
+
+// Rewrap splits text in n lines: its own lines when there are n of them,
+// otherwise its words are spread over n lines of similar length
+func Rewrap(text string, n int) []string {
+	lines := []string{}
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if len(lines) == n || n == 0 {
+		return lines
+	}
+
+	words := strings.Fields(strings.Join(lines, " "))
+	if n == 1 || len(words) <= 1 {
+		return []string{strings.Join(words, " ")}
+	}
+	total := len(strings.Join(words, " "))
+	lines = []string{}
+	line, length := "", 0
+	for i, word := range words {
+		// a line ends before the word whose middle goes past its share of
+		// the text, leaving a word for each of the lines still to fill
+		left := len(words) - i
+		if line != "" && len(lines) < n-1 && (left <= n-1-len(lines) || (2*length+len(word))*n > 2*total*(len(lines)+1)) {
+			lines = append(lines, line)
+			line = ""
+		}
+		if line != "" {
+			line += " "
+		}
+		line += word
+		length += len(word) + 1
+	}
+	return append(lines, line)
+}
@@ -0,0 +1,106 @@
+package translator
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/fedeztk/got/pkg/translator/utils"
+)
+
+func TestSplitText(t *testing.T) {
+	testCases := []struct {
+		name, text string
+		max        int
+	}{
+		{"short", "Hello World!", 100},
+		{"paragraphs", "First paragraph.\n\nSecond one.\n \n\nThird.\n", 100},
+		{"sentences", "One sentence. Another one! And a third? Yes; really.", 20},
+		{"words", "a very long sentence without any punctuation at all", 10},
+		{"runes", "ààààààààààààààààààààààààà", 5},
+		{"leading whitespace", "\n\n  text after blank lines", 100},
+		{"empty", "", 100},
+	}
+	for _, tc := range testCases {
+		chunks := SplitText(tc.text, tc.max)
+		builder := strings.Builder{}
+		for _, c := range chunks {
+			if len(c.Text) > tc.max {
+				t.Errorf("%s: chunk %q is longer than %d bytes", tc.name, c.Text, tc.max)
+			}
+			builder.WriteString(c.Text + c.Sep)
+		}
+		if joined := builder.String(); joined != tc.text {
+			t.Errorf("%s: joined chunks %q differ from the original text %q", tc.name, joined, tc.text)
+		}
+	}
+}
+
+func TestSplitTextParagraphs(t *testing.T) {
+	chunks := SplitText("First paragraph.\n\nSecond one.", 100)
+	if len(chunks) != 2 || chunks[0].Sep != "\n\n" {
+		t.Errorf("expected paragraphs to be split, got %q", chunks)
+	}
+}
+
+// recordingBackend upper cases the texts it receives, failing the ones containing fail
+type recordingBackend struct {
+	Backend
+	mu    sync.Mutex
+	texts []string
+}
+
+func (b *recordingBackend) Translate(ctx context.Context, text, source, target, engine string) (utils.BackendResponse, error) {
+	b.mu.Lock()
+	b.texts = append(b.texts, text)
+	b.mu.Unlock()
+	if strings.Contains(text, "fail") {
+		return nil, errors.New("unable to translate")
+	}
+	return utils.Result{Translation: " " + strings.ToUpper(text) + " "}, nil
+}
+
+func TestTranslateTexts(t *testing.T) {
+	b := &recordingBackend{}
+	texts := []string{"  Hello %s, you have %1$d {count} %(kind)s messages\n", "", " \t", "100%% done ${user}", "fail"}
+	results := TranslateTexts(context.Background(), b, texts, "en", "it", "google", 2, FormatPlaceholders)
+
+	want := []string{"  HELLO %s, YOU HAVE %1$d {count} %(kind)s MESSAGES\n", "", " \t", "100%% DONE ${user}", ""}
+	for i, r := range results {
+		if r.Text != want[i] {
+			t.Errorf("got %q, want %q", r.Text, want[i])
+		}
+	}
+	if results[4].Err == nil {
+		t.Error("expected the error of the failed text")
+	}
+	for _, text := range b.texts {
+		if strings.ContainsAny(text, "%{$") {
+			t.Errorf("placeholders reached the backend: %q", text)
+		}
+	}
+	if len(b.texts) != 3 {
+		t.Errorf("got %d requests, want 3 as blank texts are not sent", len(b.texts))
+	}
+}
+
+func TestRewrap(t *testing.T) {
+	testCases := []struct {
+		text string
+		n    int
+		want []string
+	}{
+		{"one\ntwo", 2, []string{"one", "two"}},
+		{"one two three four", 2, []string{"one two", "three four"}},
+		{"a longer first part\nand the rest", 1, []string{"a longer first part and the rest"}},
+		{"single", 2, []string{"single"}},
+		{"", 1, []string{""}},
+	}
+	for _, tc := range testCases {
+		if got := Rewrap(tc.text, tc.n); strings.Join(got, "|") != strings.Join(tc.want, "|") {
+			t.Errorf("Rewrap(%q, %d) = %q, want %q", tc.text, tc.n, got, tc.want)
+		}
+	}
+}
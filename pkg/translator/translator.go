@@ -1,26 +1,44 @@
-// Package translator provides a simple api for simplytranslate and lingvatranslate
+// Package translator provides a simple api for simplytranslate, lingvatranslate and libretranslate
 package translator
 
 import (
+	"context"
 	"errors"
+	"strings"
+	"time"
 
+	"github.com/fedeztk/got/pkg/translator/libretranslate"
 	"github.com/fedeztk/got/pkg/translator/lingvatranslate"
 	"github.com/fedeztk/got/pkg/translator/simplytranslate"
 	"github.com/fedeztk/got/pkg/translator/utils"
 )
 
 type Backend interface {
-	Translate(text, source, target, engine string) (utils.BackendResponse, error)
-	TextToSpeech(text, language string) ([]byte, error)
+	Translate(ctx context.Context, text, source, target, engine string) (utils.BackendResponse, error)
+	TextToSpeech(ctx context.Context, text, language string) ([]byte, error)
+	Detect(ctx context.Context, text string) (string, error)
+	Languages(ctx context.Context) ([]utils.Language, error)
 }
 
-func NewBackend(backend string) (Backend, error) {
+// Options holds the backend specific settings, backends ignore the ones they don't need
+type Options struct {
+	APIKey    string        // only used by libretranslate
+	Instances []string      // base urls of the instances to use, tried in order
+	Timeout   time.Duration // timeout of every single request, zero means no timeout
+}
+
+// Backends lists the names of the supported backends, the first one is the default
+var Backends = []string{"lingvatranslate", "simplytranslate", "libretranslate"}
+
+func NewBackend(backend string, opts Options) (Backend, error) {
 	switch backend {
 	case "lingvatranslate":
-		return lingvatranslate.New(), nil
+		return lingvatranslate.New(opts.Timeout, opts.Instances...), nil
 	case "simplytranslate":
-		return simplytranslate.New(), nil
+		return simplytranslate.New(opts.Timeout, opts.Instances...), nil
+	case "libretranslate":
+		return libretranslate.New(opts.APIKey, opts.Timeout, opts.Instances...), nil
 	default:
-		return nil, errors.New("backend not supported, please use one of the following: lingvatranslate, simplytranslate")
+		return nil, errors.New("backend not supported, please use one of the following: " + strings.Join(Backends, ", "))
 	}
 }
@@ -3,4 +3,6 @@ package utils
 type BackendResponse interface {
 	PrettyPrint() string
 	ShortTranslatedText() string
+	DetectedLanguage() string // empty if the source language was not detected
+	Result() Result
 }
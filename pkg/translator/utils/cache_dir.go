@@ -0,0 +1,15 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// CacheDir returns the directory named name inside the got cache directory
+func CacheDir(name string) string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, "got", name)
+}
@@ -0,0 +1,111 @@
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Instances is a list of base urls of the same backend, requests are sent
+// to the last instance that worked and fall back to the next ones on failure.
+// The instance that worked is remembered across runs in the cache directory
+type Instances struct {
+	urls    []string
+	current int
+	path    string // file remembering the current instance of the list
+	mu      sync.Mutex
+}
+
+// NewInstances returns the instances list for urls, or for defaultURL if urls is empty
+func NewInstances(urls []string, defaultURL string) *Instances {
+	i := &Instances{}
+	for _, u := range urls {
+		if u = strings.TrimRight(strings.TrimSpace(u), "/"); u != "" {
+			i.urls = append(i.urls, u)
+		}
+	}
+	if len(i.urls) == 0 {
+		i.urls = []string{strings.TrimRight(defaultURL, "/")}
+	}
+
+	sum := sha256.Sum256([]byte(strings.Join(i.urls, "\n")))
+	i.path = filepath.Join(CacheDir("instances"), hex.EncodeToString(sum[:]))
+	if data, err := ioutil.ReadFile(i.path); err == nil {
+		for idx, u := range i.urls {
+			if u == strings.TrimSpace(string(data)) {
+				i.current = idx
+			}
+		}
+	}
+	return i
+}
+
+// Current returns the base url of the instance that is tried first
+func (i *Instances) Current() string {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.urls[i.current]
+}
+
+// Do sends the request built by newRequest to every instance, starting from
+// the current one, until one of them answers without a network error or a
+// 5xx status code. The instance that answered becomes the current one, also
+// for the next runs.
+// A cancelled or expired request context stops the fallback immediately
+func (i *Instances) Do(client *http.Client, newRequest func(baseURL string) (*http.Request, error)) (*http.Response, error) {
+	i.mu.Lock()
+	start := i.current
+	i.mu.Unlock()
+
+	var lastErr error
+	for n := 0; n < len(i.urls); n++ {
+		idx := (start + n) % len(i.urls)
+
+		req, err := newRequest(i.urls[idx])
+		if err != nil {
+			return nil, err
+		}
+
+		res, err := client.Do(req)
+		if err != nil {
+			if ctxErr := req.Context().Err(); ctxErr != nil {
+				return nil, ctxErr
+			}
+			lastErr = err
+			continue
+		}
+		if res.StatusCode >= 500 {
+			res.Body.Close()
+			lastErr = fmt.Errorf("%s: status code received from server: %s", i.urls[idx], res.Status)
+			continue
+		}
+
+		i.mu.Lock()
+		if idx != i.current {
+			i.current = idx
+			i.save()
+		}
+		i.mu.Unlock()
+		return res, nil
+	}
+
+	if len(i.urls) == 1 {
+		return nil, lastErr
+	}
+	return nil, errors.New("all instances failed, last error: " + lastErr.Error())
+}
+
+// save remembers the current instance, best effort as failing only means
+// starting from the first instance next time
+func (i *Instances) save() {
+	if os.MkdirAll(filepath.Dir(i.path), os.ModePerm) == nil {
+		ioutil.WriteFile(i.path, []byte(i.urls[i.current]), 0o644)
+	}
+}
@@ -0,0 +1,73 @@
+package utils
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestInstancesFallback(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	broken := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer broken.Close()
+	working := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer working.Close()
+
+	instances := NewInstances([]string{"http://127.0.0.1:0", broken.URL, working.URL + "/"}, "")
+	for i := 0; i < 2; i++ {
+		res, err := instances.Do(http.DefaultClient, func(baseURL string) (*http.Request, error) {
+			return http.NewRequest("GET", baseURL, nil)
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+		if current := instances.Current(); current != working.URL {
+			t.Errorf("expected current instance to be %s, got %s", working.URL, current)
+		}
+	}
+}
+
+func TestInstancesRemembered(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	working := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer working.Close()
+
+	urls := []string{"http://127.0.0.1:0", working.URL}
+	instances := NewInstances(urls, "")
+	res, err := instances.Do(http.DefaultClient, func(baseURL string) (*http.Request, error) {
+		return http.NewRequest("GET", baseURL, nil)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	if current := NewInstances(urls, "").Current(); current != working.URL {
+		t.Errorf("expected the next run to start from %s, got %s", working.URL, current)
+	}
+	if current := NewInstances(urls[:1], "").Current(); current != urls[0] {
+		t.Errorf("expected another list to start from its first instance, got %s", current)
+	}
+}
+
+func TestInstancesAllFailing(t *testing.T) {
+	broken := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer broken.Close()
+
+	instances := NewInstances(nil, broken.URL)
+	_, err := instances.Do(http.DefaultClient, func(baseURL string) (*http.Request, error) {
+		return http.NewRequest("GET", baseURL, nil)
+	})
+	if err == nil {
+		t.Error("expected an error when every instance fails")
+	}
+}
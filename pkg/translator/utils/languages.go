@@ -2,9 +2,20 @@ package utils
 
 import (
 	_ "embed"
+	"sort"
 	"strings"
 )
 
+// Language is a language supported by a backend
+type Language struct {
+	Code       string `json:"code"`
+	Name       string `json:"name"`
+	SourceOnly bool   `json:"sourceOnly,omitempty"` // can't be used as target, e.g. auto
+}
+
+// AutoDetect makes backends detect the source language
+var AutoDetect = Language{Code: "auto", Name: "Detect language", SourceOnly: true}
+
 //go:embed languages.txt
 var languages string
 var languageMap map[string]string = loadLanguageMap()
@@ -24,3 +35,16 @@ func loadLanguageMap() map[string]string {
 func GetAllLanguages() map[string]string {
 	return languageMap
 }
+
+// GetLanguageList returns the static list of languages sorted by name, used
+// when a backend can't list the ones it supports
+func GetLanguageList() []Language {
+	list := make([]Language, 0, len(languageMap))
+	for code, name := range languageMap {
+		list = append(list, Language{Code: code, Name: name})
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name < list[j].Name
+	})
+	return append([]Language{AutoDetect}, list...)
+}
@@ -15,6 +15,8 @@ var (
 	ListItem     = IndentTwo.Copy().Bold(true)
 	TitleSecAlt  = IndentTwo.Copy().Bold(true).Background(lipgloss.Color("14")).Padding(0, 1).Foreground(lipgloss.Color("0")).MarginBottom(1).MarginTop(1)
 	TitleSecAlt2 = IndentTwo.Copy().Bold(true).Background(lipgloss.Color("11")).Padding(0, 1).Foreground(lipgloss.Color("0")).MarginBottom(1).MarginTop(1)
+	Section      = lipgloss.NewStyle().Bold(true).Underline(true).Foreground(lipgloss.Color("12"))
+	Highlight    = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("9"))
 )
 
 func PrintList(list []string) string {
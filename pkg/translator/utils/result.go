@@ -0,0 +1,136 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Result is the translation in a form shared by every backend, each backend
+// maps its own response into it and fills the fields it knows about
+type Result struct {
+	Translation       string             `json:"translation"`
+	Alternatives      []string           `json:"alternatives,omitempty"`
+	Pronunciation     string             `json:"pronunciation,omitempty"`
+	Detected          string             `json:"detectedLanguage,omitempty"`
+	Definitions       []DefinitionGroup  `json:"definitions,omitempty"`
+	Examples          []string           `json:"examples,omitempty"`
+	ExtraTranslations []TranslationGroup `json:"extraTranslations,omitempty"`
+}
+
+// DefinitionGroup holds the definitions of a part of speech
+type DefinitionGroup struct {
+	PartOfSpeech string       `json:"partOfSpeech,omitempty"`
+	Definitions  []Definition `json:"definitions"`
+}
+
+type Definition struct {
+	Definition string   `json:"definition,omitempty"`
+	Example    string   `json:"example,omitempty"`
+	Synonyms   []string `json:"synonyms,omitempty"`
+	Field      string   `json:"field,omitempty"`
+	Informal   string   `json:"informal,omitempty"`
+}
+
+// TranslationGroup holds the extra translations of a part of speech
+type TranslationGroup struct {
+	PartOfSpeech string             `json:"partOfSpeech,omitempty"`
+	Translations []ExtraTranslation `json:"translations"`
+}
+
+type ExtraTranslation struct {
+	Word      string   `json:"word"`
+	Meanings  []string `json:"meanings,omitempty"`
+	Frequency int      `json:"frequency,omitempty"`
+}
+
+func (r Result) Result() Result {
+	return r
+}
+
+func (r Result) ShortTranslatedText() string {
+	return r.Translation
+}
+
+func (r Result) DetectedLanguage() string {
+	return r.Detected
+}
+
+func (r Result) PrettyPrint() string {
+	builder := strings.Builder{}
+	if strings.Contains(r.Translation, "\n") { // long texts don't fit in the title
+		builder.WriteString(Title.Render("Translated text:") + "\n\n")
+		builder.WriteString(strings.TrimSpace(r.Translation) + "\n")
+	} else {
+		builder.WriteString(Title.Render("Translated text: "+r.Translation) + "\n")
+	}
+	if detected := r.Detected; detected != "" {
+		builder.WriteString(TitleSecAlt.Render("Detected language: "+detected) + "\n")
+	}
+	if pronunciation := r.Pronunciation; pronunciation != "" {
+		builder.WriteString(TitleSecAlt.Render("Pronunciation: "+pronunciation) + "\n")
+	}
+	if alternatives := r.Alternatives; len(alternatives) > 0 {
+		builder.WriteString(TitleSecAlt2.Render("Alternatives:") + "\n")
+		for _, alternative := range alternatives {
+			builder.WriteString(IndentTwo.Render("- "+alternative) + "\n")
+		}
+	}
+	for _, group := range r.Definitions {
+		if pos := group.PartOfSpeech; pos != "" {
+			builder.WriteString(TitleSec.Render("Part of speech: "+pos) + "\n")
+		}
+		for _, def := range group.Definitions {
+			if definition := def.Definition; definition != "" {
+				builder.WriteString(IndentTwo.Render("Definition:"))
+				builder.WriteString("\n" + IndentThree.Render("- "+definition) + "\n")
+			}
+			if example := def.Example; example != "" {
+				builder.WriteString(IndentTwo.Render("Example:"))
+				builder.WriteString("\n" + IndentThree.Render("- "+example) + "\n")
+			}
+			if synonyms := def.Synonyms; len(synonyms) > 0 {
+				builder.WriteString(IndentTwo.Render("Synonyms:"))
+				builder.WriteString("\n" + IndentThree.Render("- "))
+				builder.WriteString(PrintList(synonyms))
+			}
+			if field := def.Field; field != "" {
+				builder.WriteString(IndentTwo.Render("Field:"))
+				builder.WriteString("\n" + IndentThree.Render("- "+field) + "\n")
+			}
+			if informal := def.Informal; informal != "" {
+				builder.WriteString(IndentTwo.Render("Informal: "+informal) + "\n")
+			}
+			builder.WriteString("\n")
+		}
+	}
+	if examples := r.Examples; len(examples) > 0 {
+		builder.WriteString(TitleSecAlt2.Render("Examples:") + "\n")
+		for _, example := range examples {
+			builder.WriteString(IndentTwo.Render("- "+example) + "\n")
+		}
+	}
+	if extraTranslations := r.ExtraTranslations; len(extraTranslations) > 0 {
+		builder.WriteString("\n" + Title.Render("Extra translations:") + "\n")
+		for _, group := range extraTranslations {
+			if pos := group.PartOfSpeech; pos != "" {
+				builder.WriteString(TitleSec.Render("Part of speech: "+pos) + "\n")
+			}
+			for _, t := range group.Translations {
+				if word := t.Word; word != "" {
+					builder.WriteString(IndentTwo.Render("Word:"))
+					builder.WriteString("\n" + IndentThree.Render("- "+word) + "\n")
+				}
+				if meanings := t.Meanings; len(meanings) > 0 {
+					builder.WriteString(IndentTwo.Render("Meaning:"))
+					builder.WriteString("\n" + IndentThree.Render("- "))
+					builder.WriteString(PrintList(meanings))
+				}
+				if frequency := t.Frequency; frequency > 0 {
+					builder.WriteString(IndentTwo.Render(fmt.Sprintf("Frequency: %d", frequency)) + "\n")
+				}
+			}
+		}
+	}
+
+	return builder.String()
+}
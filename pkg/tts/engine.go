@@ -0,0 +1,77 @@
+package tts
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// placeholders replaced in the arguments of an engine
+const (
+	langPlaceholder = "{lang}" // language of the text
+	outPlaceholder  = "{out}"  // wav file the engine writes, stdout is read when missing
+)
+
+// Engine is a speech engine run as an external program, the text is written to
+// its standard input and the wav audio read from its output
+type Engine struct {
+	Path string
+	Args []string
+}
+
+// engines are the speech engines known by name, with the arguments they need
+var engines = map[string]Engine{
+	"espeak-ng": {Path: "espeak-ng", Args: []string{"--stdout", "-v", langPlaceholder}},
+	"espeak":    {Path: "espeak", Args: []string{"--stdout", "-v", langPlaceholder}},
+	"piper":     {Path: "piper", Args: []string{"--output_file", outPlaceholder}},
+	"festival":  {Path: "text2wave", Args: []string{"-o", outPlaceholder}},
+}
+
+// NewEngine returns the speech engine called name (espeak-ng, espeak, piper or
+// festival) with args appended to its arguments, e.g. the voice model of piper
+func NewEngine(name string, args ...string) (Engine, error) {
+	e, ok := engines[name]
+	if !ok {
+		return Engine{}, fmt.Errorf("unknown speech engine %s, could be: espeak-ng, espeak, piper, festival", name)
+	}
+	e.Args = append(append([]string{}, e.Args...), args...)
+	return e, nil
+}
+
+func (e Engine) TextToSpeech(ctx context.Context, text, language string) ([]byte, error) {
+	var out string
+	args := make([]string, len(e.Args))
+	for i, a := range e.Args {
+		if strings.Contains(a, outPlaceholder) && out == "" {
+			dir, err := ioutil.TempDir("", "got-tts")
+			if err != nil {
+				return nil, err
+			}
+			defer os.RemoveAll(dir)
+			out = filepath.Join(dir, "speech.wav")
+		}
+		a = strings.ReplaceAll(a, outPlaceholder, out)
+		args[i] = strings.ReplaceAll(a, langPlaceholder, language)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, e.Path, args...)
+	cmd.Stdin = strings.NewReader(text)
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%s: %v: %s", filepath.Base(e.Path), err, msg)
+		}
+		return nil, fmt.Errorf("%s: %v", filepath.Base(e.Path), err)
+	}
+
+	if out != "" {
+		return ioutil.ReadFile(out)
+	}
+	return stdout.Bytes(), nil
+}
@@ -0,0 +1,89 @@
+// Package tts provides text to speech providers other than the translation
+// backends, such as speech engines installed on the local machine
+package tts
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Provider turns text into speech, every translator.Backend is a Provider
+type Provider interface {
+	// TextToSpeech returns the audio of text spoken in language, either mp3 or wav
+	TextToSpeech(ctx context.Context, text, language string) ([]byte, error)
+}
+
+// DefaultLanguage is the key of the provider used for the languages without one
+const DefaultLanguage = "default"
+
+// Languages chooses the provider by the language of the text
+type Languages map[string]Provider
+
+func (l Languages) TextToSpeech(ctx context.Context, text, language string) ([]byte, error) {
+	p, ok := l[language]
+	if !ok {
+		p, ok = l[DefaultLanguage]
+	}
+	if !ok {
+		return nil, fmt.Errorf("no speech engine configured for %s", language)
+	}
+	return p.TextToSpeech(ctx, text, language)
+}
+
+// Fallback asks every provider in order until one of them answers
+type Fallback []Provider
+
+func (f Fallback) TextToSpeech(ctx context.Context, text, language string) ([]byte, error) {
+	var errs []string
+	for _, p := range f {
+		audio, err := p.TextToSpeech(ctx, text, language)
+		if err == nil {
+			return audio, nil
+		}
+		if ctx.Err() != nil {
+			return nil, err
+		}
+		errs = append(errs, err.Error())
+	}
+	return nil, fmt.Errorf("%s", strings.Join(errs, ", then "))
+}
+
+// FromConfig returns the local speech engines of engines, mapping each language
+// (or DefaultLanguage) to the command line of the engine, e.g. "piper -m ~/it.onnx"
+func FromConfig(engines map[string]string) (Languages, error) {
+	l := make(Languages, len(engines))
+	for lang, cmdline := range engines {
+		fields := strings.Fields(cmdline)
+		if len(fields) == 0 {
+			return nil, fmt.Errorf("empty speech engine for %s", lang)
+		}
+		for i, f := range fields {
+			if strings.HasPrefix(f, "~/") {
+				home, _ := os.UserHomeDir()
+				fields[i] = filepath.Join(home, f[2:])
+			}
+		}
+		e, err := NewEngine(fields[0], fields[1:]...)
+		if err != nil {
+			return nil, err
+		}
+		l[lang] = e
+	}
+	return l, nil
+}
+
+// WithFallback returns p falling back to the local speech engines of engines
+// (see FromConfig) when it fails, p itself when there are none
+func WithFallback(p Provider, engines map[string]string) (Provider, error) {
+	if len(engines) == 0 {
+		return p, nil
+	}
+	local, err := FromConfig(engines)
+	if err != nil {
+		return nil, err
+	}
+	return Fallback{p, local}, nil
+}
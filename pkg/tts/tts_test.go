@@ -0,0 +1,79 @@
+package tts
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type fakeProvider struct {
+	audio string
+	err   error
+}
+
+func (p fakeProvider) TextToSpeech(ctx context.Context, text, language string) ([]byte, error) {
+	return []byte(p.audio + " " + language), p.err
+}
+
+func TestEngineStdout(t *testing.T) {
+	e := Engine{Path: "sh", Args: []string{"-c", "echo {lang}; cat"}}
+	audio, err := e.TextToSpeech(context.Background(), "ciao", "it")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(audio) != "it\nciao" {
+		t.Errorf("unexpected audio %q", audio)
+	}
+}
+
+func TestEngineOutputFile(t *testing.T) {
+	e := Engine{Path: "sh", Args: []string{"-c", "cat > $0", outPlaceholder}}
+	audio, err := e.TextToSpeech(context.Background(), "ciao", "it")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(audio) != "ciao" {
+		t.Errorf("unexpected audio %q", audio)
+	}
+}
+
+func TestEngineError(t *testing.T) {
+	e := Engine{Path: "sh", Args: []string{"-c", "echo broken >&2; exit 1"}}
+	if _, err := e.TextToSpeech(context.Background(), "ciao", "it"); err == nil || err.Error() != "sh: exit status 1: broken" {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func TestFromConfig(t *testing.T) {
+	l, err := FromConfig(map[string]string{"default": "espeak-ng", "it": "piper -m it.onnx"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	piper := l["it"].(Engine)
+	if piper.Path != "piper" || piper.Args[len(piper.Args)-1] != "it.onnx" {
+		t.Errorf("unexpected engine %+v", piper)
+	}
+
+	if _, err := FromConfig(map[string]string{"it": "say"}); err == nil {
+		t.Error("expected an error for an unknown engine")
+	}
+}
+
+func TestLanguagesAndFallback(t *testing.T) {
+	l := Languages{"it": fakeProvider{audio: "it engine"}, DefaultLanguage: fakeProvider{audio: "default engine"}}
+	p := Fallback{fakeProvider{err: errors.New("backend down")}, l}
+
+	for lang, want := range map[string]string{"it": "it engine it", "de": "default engine de"} {
+		audio, err := p.TextToSpeech(context.Background(), "text", lang)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(audio) != want {
+			t.Errorf("expected %q, got %q", want, audio)
+		}
+	}
+
+	if _, err := (Fallback{fakeProvider{err: errors.New("backend down")}, Languages{}}).TextToSpeech(context.Background(), "text", "it"); err == nil {
+		t.Error("expected an error when every provider fails")
+	}
+}